@@ -0,0 +1,199 @@
+package arena
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// z95 is the z-score of the 95% confidence interval.
+const z95 = 1.96
+
+// BatchConfig holds the settings of a series of matches between two bots.
+type BatchConfig struct {
+	Config
+	// Games is the number of matches, every seed is played twice with swapped sides.
+	Games int
+	// Parallel is the number of matches played at once, the number of CPUs by default.
+	Parallel int
+}
+
+// BotStats are the per-bot totals of a batch.
+type BotStats struct {
+	Name        string
+	AvgTurnTime time.Duration
+	MaxTurnTime time.Duration
+	Timeouts    int
+	Errors      int
+}
+
+// Stats is the outcome of a batch from the point of view of the first bot.
+type Stats struct {
+	Games  int
+	Wins   int
+	Draws  int
+	Losses int
+	// Score is the mean result of a game: 1 for a win, 0.5 for a draw.
+	Score float64
+	// ScoreError is the half-width of the 95% confidence interval of the Score.
+	ScoreError float64
+	// Elo is the rating difference of the first bot, EloLow and EloHigh bound the 95% interval.
+	Elo     float64
+	EloLow  float64
+	EloHigh float64
+	Bots    [2]BotStats
+}
+
+func (s Stats) String() string {
+	str := fmt.Sprintf("games %d: +%d =%d -%d, score %.1f%% ± %.1f%%, elo %+.0f [%+.0f, %+.0f]",
+		s.Games, s.Wins, s.Draws, s.Losses, s.Score*100, s.ScoreError*100, s.Elo, s.EloLow, s.EloHigh)
+	for _, b := range s.Bots {
+		str += fmt.Sprintf("\n%s: turn avg %s max %s, timeouts %d, errors %d",
+			b.Name, b.AvgTurnTime, b.MaxTurnTime, b.Timeouts, b.Errors)
+	}
+	return str
+}
+
+// PlayBatch plays the matches between two bots in parallel and returns the statistics.
+// Game i uses the seed Seed+i/2, odd games swap the sides.
+func PlayBatch(newReferee func() Referee, a, b Bot, conf BatchConfig) (Stats, error) {
+	parallel := conf.Parallel
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+
+	games := make(chan int)
+	results := make([]Result, conf.Games)
+	errs := make([]error, conf.Games)
+
+	var wg sync.WaitGroup
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range games {
+				gc := conf.Config
+				gc.Seed = conf.Seed + int64(i/2)
+				bots := []Bot{a, b}
+				if i%2 == 1 {
+					bots = []Bot{b, a}
+				}
+				replay, err := Play(newReferee(), bots, gc)
+				if err != nil {
+					errs[i] = err
+					continue
+				}
+				results[i] = replay.Result
+				if i%2 == 1 {
+					results[i] = swapResult(results[i])
+				}
+			}
+		}()
+	}
+	for i := 0; i < conf.Games; i++ {
+		games <- i
+	}
+	close(games)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return Stats{}, err
+		}
+	}
+
+	stats := Summarize(results)
+	stats.Bots[0].Name = a.Name
+	stats.Bots[1].Name = b.Name
+
+	return stats, nil
+}
+
+// swapResult returns the result of two players with the sides swapped.
+func swapResult(r Result) Result {
+	r.Players = []string{r.Players[1], r.Players[0]}
+	r.Scores = []float64{r.Scores[1], r.Scores[0]}
+	r.Errors = []string{r.Errors[1], r.Errors[0]}
+	r.Ranks = []int{r.Ranks[1], r.Ranks[0]}
+	r.TurnTimes = []time.Duration{r.TurnTimes[1], r.TurnTimes[0]}
+	r.MaxTurnTimes = []time.Duration{r.MaxTurnTimes[1], r.MaxTurnTimes[0]}
+	r.Timeouts = []int{r.Timeouts[1], r.Timeouts[0]}
+	return r
+}
+
+// Summarize returns the statistics of two player results in the same order of players.
+func Summarize(results []Result) Stats {
+	var s Stats
+	var turnTimes [2]time.Duration
+	var turns int
+	points := make([]float64, 0, len(results))
+
+	for _, r := range results {
+		s.Games++
+		switch {
+		case r.Ranks[0] < r.Ranks[1]:
+			s.Wins++
+			points = append(points, 1)
+		case r.Ranks[0] > r.Ranks[1]:
+			s.Losses++
+			points = append(points, 0)
+		default:
+			s.Draws++
+			points = append(points, 0.5)
+		}
+
+		turns += r.Turns
+		for i := range s.Bots {
+			turnTimes[i] += r.TurnTimes[i]
+			if r.MaxTurnTimes[i] > s.Bots[i].MaxTurnTime {
+				s.Bots[i].MaxTurnTime = r.MaxTurnTimes[i]
+			}
+			s.Bots[i].Timeouts += r.Timeouts[i]
+			if r.Errors[i] != "" {
+				s.Bots[i].Errors++
+			}
+		}
+	}
+	if s.Games == 0 {
+		return s
+	}
+
+	if turns > 0 {
+		for i := range s.Bots {
+			s.Bots[i].AvgTurnTime = turnTimes[i] / time.Duration(turns)
+		}
+	}
+
+	n := float64(s.Games)
+	for _, p := range points {
+		s.Score += p
+	}
+	s.Score /= n
+
+	var variance float64
+	for _, p := range points {
+		variance += (p - s.Score) * (p - s.Score)
+	}
+	variance /= n
+	s.ScoreError = z95 * math.Sqrt(variance/n)
+
+	// a score of 0 or 1 has an infinite rating, clamp to half a game
+	limit := 0.5 / n
+	s.Elo = Elo(clamp(s.Score, limit, 1-limit))
+	s.EloLow = Elo(clamp(s.Score-s.ScoreError, limit, 1-limit))
+	s.EloHigh = Elo(clamp(s.Score+s.ScoreError, limit, 1-limit))
+
+	return s
+}
+
+// Elo returns the rating difference of the expected score.
+func Elo(score float64) float64 {
+	return 400 * math.Log10(score/(1-score))
+}
+
+// clamp returns the value limited to the range.
+func clamp(x, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, x))
+}
@@ -0,0 +1,91 @@
+package arena
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElo(t *testing.T) {
+	assert.Equal(t, 0.0, Elo(0.5))
+	assert.InDelta(t, 191, Elo(0.75), 1)
+	assert.InDelta(t, -191, Elo(0.25), 1)
+}
+
+func newSummarizeTests(ranks ...[]int) []Result {
+	results := make([]Result, 0, len(ranks))
+	for _, r := range ranks {
+		results = append(results, Result{
+			Ranks:        r,
+			Errors:       []string{"", ""},
+			Turns:        10,
+			TurnTimes:    []time.Duration{10 * time.Millisecond, 20 * time.Millisecond},
+			MaxTurnTimes: []time.Duration{2 * time.Millisecond, 4 * time.Millisecond},
+			Timeouts:     []int{0, 0},
+		})
+	}
+	return results
+}
+
+func TestSummarize(t *testing.T) {
+	results := newSummarizeTests([]int{0, 1}, []int{0, 1}, []int{0, 0}, []int{1, 0})
+	results[3].Errors[1] = "timeout"
+	results[3].Timeouts[1] = 1
+
+	s := Summarize(results)
+	assert.Equal(t, 4, s.Games)
+	assert.Equal(t, 2, s.Wins)
+	assert.Equal(t, 1, s.Draws)
+	assert.Equal(t, 1, s.Losses)
+	assert.Equal(t, 0.625, s.Score)
+	assert.InDelta(t, 0.406, s.ScoreError, 0.001)
+	assert.InDelta(t, 89, s.Elo, 1)
+	assert.Less(t, s.EloLow, 0.0)
+	assert.Greater(t, s.EloHigh, s.Elo)
+
+	assert.Equal(t, time.Millisecond, s.Bots[0].AvgTurnTime)
+	assert.Equal(t, 2*time.Millisecond, s.Bots[1].AvgTurnTime)
+	assert.Equal(t, 4*time.Millisecond, s.Bots[1].MaxTurnTime)
+	assert.Equal(t, 1, s.Bots[1].Timeouts)
+	assert.Equal(t, 1, s.Bots[1].Errors)
+}
+
+func TestSummarize_AllWins(t *testing.T) {
+	s := Summarize(newSummarizeTests([]int{0, 1}, []int{0, 1}))
+	assert.Equal(t, 1.0, s.Score)
+	assert.InDelta(t, 191, s.Elo, 1)
+	assert.Equal(t, s.Elo, s.EloHigh)
+}
+
+func TestSwapResult(t *testing.T) {
+	r := newSummarizeTests([]int{0, 1})[0]
+	r.Players = []string{"a", "b"}
+	r.Scores = []float64{1, 2}
+
+	s := swapResult(r)
+	assert.Equal(t, []string{"b", "a"}, s.Players)
+	assert.Equal(t, []float64{2, 1}, s.Scores)
+	assert.Equal(t, []int{1, 0}, s.Ranks)
+	assert.Equal(t, []time.Duration{20 * time.Millisecond, 10 * time.Millisecond}, s.TurnTimes)
+}
+
+func TestPlayBatch(t *testing.T) {
+	conf := BatchConfig{Config: DefaultConfig(), Games: 4, Parallel: 2}
+	newReferee := func() Referee { return &MockReferee{Turns: 3} }
+
+	stats, err := PlayBatch(newReferee, testBot("target"), testBot("zero"), conf)
+	require.NoError(t, err)
+	assert.Equal(t, 4, stats.Games)
+	assert.Equal(t, 4, stats.Wins)
+	assert.Equal(t, "target", stats.Bots[0].Name)
+	assert.Equal(t, "zero", stats.Bots[1].Name)
+	assert.Contains(t, stats.String(), "games 4: +4 =0 -0")
+}
+
+func TestPlayBatch_InvalidBot(t *testing.T) {
+	conf := BatchConfig{Config: DefaultConfig(), Games: 2}
+	_, err := PlayBatch(func() Referee { return &MockReferee{} }, Bot{}, testBot("zero"), conf)
+	assert.Error(t, err)
+}
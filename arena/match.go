@@ -0,0 +1,192 @@
+package arena
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"time"
+)
+
+// SeedEnv is the environment variable passing the random seed to the bot,
+// the player i of the match gets the Seed+i.
+const SeedEnv = "BOT_SEED"
+
+// Config holds the settings of a match.
+type Config struct {
+	Seed int64
+	// FirstTurnTimeout is the time to answer the first turn, including the game input.
+	FirstTurnTimeout time.Duration
+	// TurnTimeout is the time to answer every next turn.
+	TurnTimeout time.Duration
+}
+
+// DefaultConfig returns the CodinGame time limits.
+func DefaultConfig() Config {
+	return Config{
+		FirstTurnTimeout: time.Second,
+		TurnTimeout:      50 * time.Millisecond,
+	}
+}
+
+// Result is the outcome of a match.
+type Result struct {
+	Seed    int64
+	Players []string
+	Scores  []float64
+	// Errors are the disqualification reasons, empty for players not disqualified.
+	Errors []string
+	// Ranks are 0 for the winners, disqualified players are ranked last.
+	Ranks []int
+	Turns int
+	// TurnTimes are the total answer times of the players.
+	TurnTimes []time.Duration
+	// MaxTurnTimes are the longest answer times of the players.
+	MaxTurnTimes []time.Duration
+	Timeouts     []int
+}
+
+// Frame is a recorded turn of the match.
+type Frame struct {
+	Turn    int
+	Inputs  [][]string
+	Outputs [][]string
+	Stderr  []string
+	Times   []time.Duration
+}
+
+// Replay is the recording of a match.
+type Replay struct {
+	Result Result
+	Frames []Frame
+}
+
+// Save writes the replay as JSON.
+func (r *Replay) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Play runs the match between the bots and returns the replay with the result.
+func Play(ref Referee, bots []Bot, conf Config) (*Replay, error) {
+	n := len(bots)
+	result := Result{
+		Seed:         conf.Seed,
+		Players:      make([]string, n),
+		Errors:       make([]string, n),
+		TurnTimes:    make([]time.Duration, n),
+		MaxTurnTimes: make([]time.Duration, n),
+		Timeouts:     make([]int, n),
+	}
+	replay := &Replay{}
+
+	players := make([]*player, 0, n)
+	stop := func() {
+		for _, p := range players {
+			p.stop()
+		}
+	}
+	for i, bot := range bots {
+		result.Players[i] = bot.Name
+		// the seed set by the bot environment takes precedence
+		bot.Env = append([]string{SeedEnv + "=" + strconv.FormatInt(conf.Seed+int64(i), 10)}, bot.Env...)
+		p, err := startPlayer(bot)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		players = append(players, p)
+	}
+
+	ref.Init(n, conf.Seed)
+	alive := n
+	// a solo game runs while the player is alive, others while there are opponents
+	for running := true; running && alive > 0 && (n == 1 || alive > 1); result.Turns++ {
+		frame := Frame{
+			Turn:    result.Turns,
+			Inputs:  make([][]string, n),
+			Outputs: make([][]string, n),
+			Stderr:  make([]string, n),
+			Times:   make([]time.Duration, n),
+		}
+
+		for i, p := range players {
+			if result.Errors[i] != "" {
+				continue
+			}
+
+			timeout := conf.TurnTimeout
+			input := ref.TurnInput(i)
+			if result.Turns == 0 {
+				timeout = conf.FirstTurnTimeout
+				input = append(ref.GameInput(i), input...)
+			}
+			frame.Inputs[i] = input
+
+			start := time.Now()
+			err := p.send(input)
+			if err == nil {
+				frame.Outputs[i], err = p.receive(ref.OutputLines(i), timeout)
+			}
+			frame.Times[i] = time.Since(start)
+			frame.Stderr[i] = p.stderr.Flush()
+
+			result.TurnTimes[i] += frame.Times[i]
+			if frame.Times[i] > result.MaxTurnTimes[i] {
+				result.MaxTurnTimes[i] = frame.Times[i]
+			}
+			if err == ErrTimeout {
+				result.Timeouts[i]++
+			}
+			if err == nil {
+				err = ref.Play(i, frame.Outputs[i])
+			}
+			if err != nil {
+				result.Errors[i] = err.Error()
+				alive--
+			}
+		}
+
+		replay.Frames = append(replay.Frames, frame)
+		running = ref.Advance()
+	}
+
+	// the rest of the debug output is written until the bots exit
+	stop()
+	last := &replay.Frames[len(replay.Frames)-1]
+	for i, p := range players {
+		last.Stderr[i] += p.stderr.Flush()
+	}
+
+	result.Scores = ref.Scores()
+	result.Ranks = rank(result.Scores, result.Errors)
+	replay.Result = result
+
+	return replay, nil
+}
+
+// rank returns the ranks of the players by score, disqualified players are ranked last.
+func rank(scores []float64, errors []string) []int {
+	ranks := make([]int, len(scores))
+	for i := range scores {
+		for j := range scores {
+			if i == j {
+				continue
+			}
+			if better(j, i, scores, errors) {
+				ranks[i]++
+			}
+		}
+	}
+	return ranks
+}
+
+// better tests if the player a is ranked above the player b.
+func better(a, b int, scores []float64, errors []string) bool {
+	if (errors[a] == "") != (errors[b] == "") {
+		return errors[a] == ""
+	}
+	return scores[a] > scores[b]
+}
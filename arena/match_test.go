@@ -0,0 +1,174 @@
+package arena
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain runs the test binary as a bot when the mode is set.
+func TestMain(m *testing.M) {
+	if mode := os.Getenv("ARENA_TEST_BOT"); mode != "" {
+		runTestBot(mode)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runTestBot plays the mock game aiming at the target, at zero,
+// too slow or exiting after the first turn. The aim mode scales the target
+// by the "aim" parameter, the seed mode prints the seed.
+func runTestBot(mode string) {
+	if mode == "seed" {
+		fmt.Fprintln(os.Stderr, "seed", os.Getenv(SeedEnv))
+	}
+
+	aim := 1.0
+	for _, pair := range strings.Split(os.Getenv(ParamsEnv), ",") {
+		if strings.HasPrefix(pair, "aim=") {
+			aim, _ = strconv.ParseFloat(strings.TrimPrefix(pair, "aim="), 64)
+		}
+	}
+
+	s := bufio.NewScanner(os.Stdin)
+	s.Scan()
+	n, _ := strconv.Atoi(s.Text())
+	var x, y float64
+	for i := 0; i < n; i++ {
+		s.Scan()
+		var ux, uy, uz float64
+		fmt.Sscan(s.Text(), &ux, &uy, &uz)
+		x += ux / float64(n)
+		y += uy / float64(n)
+	}
+
+	for turn := 0; s.Scan(); turn++ {
+		var power float64
+		fmt.Sscan(s.Text(), &power)
+		fmt.Fprintln(os.Stderr, "turn", turn)
+		switch mode {
+		case "zero":
+			fmt.Println("0 0")
+		case "slow":
+			time.Sleep(200 * time.Millisecond)
+			fmt.Println("0 0")
+		case "aim":
+			fmt.Println(x*power*aim, y*power*aim)
+		case "exit":
+			if turn > 0 {
+				return
+			}
+			fmt.Println("0 0")
+		default:
+			fmt.Println(x*power, y*power)
+		}
+	}
+}
+
+func testBot(mode string) Bot {
+	return Bot{
+		Name:    mode,
+		Command: []string{os.Args[0]},
+		Env:     []string{"ARENA_TEST_BOT=" + mode},
+	}
+}
+
+func TestPlay(t *testing.T) {
+	ref := &MockReferee{Turns: 5}
+	conf := DefaultConfig()
+	conf.Seed = 1
+
+	replay, err := Play(ref, []Bot{testBot("target"), testBot("zero")}, conf)
+	require.NoError(t, err)
+
+	result := replay.Result
+	assert.Equal(t, []string{"target", "zero"}, result.Players)
+	assert.Equal(t, 5, result.Turns)
+	assert.Equal(t, []string{"", ""}, result.Errors)
+	assert.Equal(t, []int{0, 1}, result.Ranks)
+	assert.Equal(t, 50.0, result.Scores[0])
+	assert.Less(t, result.Scores[1], 50.0)
+
+	require.Len(t, replay.Frames, 5)
+	assert.Equal(t, ref.GameInput(0), replay.Frames[0].Inputs[0][:len(ref.GameInput(0))])
+	assert.Len(t, replay.Frames[1].Inputs[0], 1)
+	var stderr string
+	for _, frame := range replay.Frames {
+		stderr += frame.Stderr[1]
+	}
+	assert.Equal(t, "turn 0\nturn 1\nturn 2\nturn 3\nturn 4\n", stderr)
+}
+
+func TestPlay_Seed(t *testing.T) {
+	conf := DefaultConfig()
+	conf.Seed = 7
+
+	replay, err := Play(&MockReferee{Turns: 1}, []Bot{testBot("seed"), testBot("seed")}, conf)
+	require.NoError(t, err)
+	assert.Equal(t, "seed 7\nturn 0\n", replay.Frames[0].Stderr[0])
+	assert.Equal(t, "seed 8\nturn 0\n", replay.Frames[0].Stderr[1])
+}
+
+func TestPlay_Timeout(t *testing.T) {
+	conf := DefaultConfig()
+	conf.FirstTurnTimeout = 50 * time.Millisecond
+
+	replay, err := Play(&MockReferee{Turns: 5}, []Bot{testBot("slow"), testBot("zero")}, conf)
+	require.NoError(t, err)
+
+	result := replay.Result
+	assert.Equal(t, 1, result.Turns)
+	assert.Equal(t, ErrTimeout.Error(), result.Errors[0])
+	assert.Equal(t, []int{1, 0}, result.Timeouts)
+	assert.Equal(t, []int{1, 0}, result.Ranks)
+}
+
+func TestPlay_Exit(t *testing.T) {
+	conf := DefaultConfig()
+	// the exit is told from a timeout by the closed output, a slow exit under the race detector
+	// must not run out of the turn time
+	conf.TurnTimeout = 5 * time.Second
+
+	replay, err := Play(&MockReferee{Turns: 5}, []Bot{testBot("zero"), testBot("exit")}, conf)
+	require.NoError(t, err)
+
+	result := replay.Result
+	assert.Equal(t, 2, result.Turns)
+	assert.Contains(t, result.Errors[1], "bot exited")
+	assert.Equal(t, []int{0, 1}, result.Ranks)
+}
+
+func TestPlay_Solo(t *testing.T) {
+	replay, err := Play(&MockReferee{Turns: 3}, []Bot{testBot("target")}, DefaultConfig())
+	require.NoError(t, err)
+	assert.Equal(t, 3, replay.Result.Turns)
+	assert.Equal(t, []float64{30}, replay.Result.Scores)
+}
+
+func TestPlay_InvalidBot(t *testing.T) {
+	_, err := Play(&MockReferee{}, []Bot{{Name: "none"}}, DefaultConfig())
+	assert.Error(t, err)
+}
+
+func TestRank(t *testing.T) {
+	assert.Equal(t, []int{2, 0, 0}, rank([]float64{1, 2, 2}, []string{"", "", ""}))
+	assert.Equal(t, []int{0, 1}, rank([]float64{1, 2}, []string{"", "timeout"}))
+}
+
+func TestParseBot(t *testing.T) {
+	bot := ParseBot("./bot -depth 3")
+	assert.Equal(t, []string{"./bot", "-depth", "3"}, bot.Command)
+	assert.Equal(t, "./bot -depth 3", bot.Name)
+}
+
+func TestNewReferee(t *testing.T) {
+	assert.IsType(t, &MockReferee{}, NewReferee("mock"))
+	assert.Nil(t, NewReferee("unknown"))
+}
@@ -0,0 +1,87 @@
+package arena
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// MockReferee is the referee of the example game of the framework.
+// The game input is a list of units "x y z", every turn the input is "power L R"
+// and a player answers with a point "x y". The player scores on every turn
+// the closer the point is to the center of the units scaled by the power.
+type MockReferee struct {
+	Turns int
+
+	rnd    *rand.Rand
+	units  [][3]float64
+	power  float64
+	turn   int
+	scores []float64
+}
+
+func (r *MockReferee) Init(players int, seed int64) {
+	r.rnd = rand.New(rand.NewSource(seed))
+	r.units = make([][3]float64, 1+r.rnd.Intn(5))
+	for i := range r.units {
+		for j := range r.units[i] {
+			r.units[i][j] = float64(r.rnd.Intn(10))
+		}
+	}
+	r.turn = 0
+	r.scores = make([]float64, players)
+	r.next()
+}
+
+// next starts a new turn.
+func (r *MockReferee) next() {
+	r.power = float64(1 + r.rnd.Intn(10))
+}
+
+func (r *MockReferee) GameInput(player int) []string {
+	lines := []string{strconv.Itoa(len(r.units))}
+	for _, u := range r.units {
+		lines = append(lines, fmt.Sprintf("%.f %.f %.f", u[0], u[1], u[2]))
+	}
+	return lines
+}
+
+func (r *MockReferee) TurnInput(player int) []string {
+	return []string{fmt.Sprintf("%.f L R", r.power)}
+}
+
+func (r *MockReferee) OutputLines(player int) int {
+	return 1
+}
+
+// Target returns the point scoring the most on the current turn.
+func (r *MockReferee) Target() (float64, float64) {
+	var x, y float64
+	for _, u := range r.units {
+		x += u[0]
+		y += u[1]
+	}
+	n := float64(len(r.units))
+	return x / n * r.power, y / n * r.power
+}
+
+func (r *MockReferee) Play(player int, output []string) error {
+	var x, y float64
+	if _, err := fmt.Sscan(output[0], &x, &y); err != nil {
+		return fmt.Errorf("invalid command %q: %w", output[0], err)
+	}
+	tx, ty := r.Target()
+	r.scores[player] += math.Max(0, 10-math.Hypot(x-tx, y-ty))
+	return nil
+}
+
+func (r *MockReferee) Advance() bool {
+	r.turn++
+	r.next()
+	return r.turn < r.Turns
+}
+
+func (r *MockReferee) Scores() []float64 {
+	return r.scores
+}
@@ -0,0 +1,147 @@
+package arena
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrTimeout is returned when the player does not answer in time.
+var ErrTimeout = errors.New("timeout")
+
+// Bot is a bot binary taking part in a match.
+type Bot struct {
+	Name string
+	// Command is the command line of the bot.
+	Command []string
+	// Env is added to the environment of the bot process.
+	Env []string
+}
+
+// ParseBot returns the Bot of the space separated command line.
+func ParseBot(command string) Bot {
+	return Bot{Name: command, Command: strings.Fields(command)}
+}
+
+// player is a running bot process.
+type player struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	lines  chan string
+	stderr lockedBuffer
+}
+
+// lockedBuffer is a buffer safe for the concurrent writes of the process.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// Flush returns the written data and resets the buffer.
+func (b *lockedBuffer) Flush() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	s := b.buf.String()
+	b.buf.Reset()
+	return s
+}
+
+// startPlayer starts the bot process.
+func startPlayer(bot Bot) (*player, error) {
+	if len(bot.Command) == 0 {
+		return nil, errors.New("empty bot command")
+	}
+
+	p := &player{
+		cmd:   exec.Command(bot.Command[0], bot.Command[1:]...),
+		lines: make(chan string, 64),
+	}
+	p.cmd.Env = append(os.Environ(), bot.Env...)
+	p.cmd.Stderr = &p.stderr
+
+	var err error
+	if p.stdin, err = p.cmd.StdinPipe(); err != nil {
+		return nil, err
+	}
+	stdout, err := p.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err = p.cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		s := bufio.NewScanner(stdout)
+		s.Buffer(make([]byte, 1000000), 1000000)
+		for s.Scan() {
+			p.lines <- s.Text()
+		}
+		close(p.lines)
+	}()
+
+	return p, nil
+}
+
+// send writes the input lines to the bot.
+func (p *player) send(lines []string) error {
+	_, err := io.WriteString(p.stdin, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// receive reads the output lines of the bot within the timeout.
+func (p *player) receive(n int, timeout time.Duration) ([]string, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	output := make([]string, 0, n)
+	for len(output) < n {
+		select {
+		case line, ok := <-p.lines:
+			if !ok {
+				return output, fmt.Errorf("bot exited: %w", io.ErrUnexpectedEOF)
+			}
+			output = append(output, line)
+		case <-timer.C:
+			return output, ErrTimeout
+		}
+	}
+
+	return output, nil
+}
+
+// stop closes the input of the bot and kills the process if it does not exit.
+func (p *player) stop() {
+	p.stdin.Close()
+	// the output is not read anymore
+	go func() {
+		for range p.lines {
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		p.cmd.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(100 * time.Millisecond):
+		p.cmd.Process.Kill()
+		<-done
+	}
+}
@@ -0,0 +1,35 @@
+// Package arena runs local matches between bot binaries
+// over the CodinGame protocol: input lines on stdin, commands on stdout.
+package arena
+
+// Referee implements the rules of a game.
+type Referee interface {
+	// Init starts a new game for the number of players.
+	Init(players int, seed int64)
+	// GameInput returns the initial input lines of the player.
+	GameInput(player int) []string
+	// TurnInput returns the turn input lines of the player.
+	TurnInput(player int) []string
+	// OutputLines returns the number of command lines expected from the player.
+	OutputLines(player int) int
+	// Play consumes the commands of the player, an error disqualifies the player.
+	Play(player int, output []string) error
+	// Advance resolves the turn and returns false when the game is over.
+	Advance() bool
+	// Scores returns the scores of the players, the higher is better.
+	Scores() []float64
+}
+
+// referees are the available games by name.
+var referees = map[string]func() Referee{
+	"mock": func() Referee { return &MockReferee{Turns: 20} },
+}
+
+// NewReferee returns the referee of the game or nil if the game is unknown.
+func NewReferee(game string) Referee {
+	newReferee, ok := referees[game]
+	if !ok {
+		return nil
+	}
+	return newReferee()
+}
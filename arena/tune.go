@@ -0,0 +1,158 @@
+package arena
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParamsEnv is the environment variable passing the parameters to the bot.
+const ParamsEnv = "BOT_PARAMS"
+
+// Param is a tuned parameter of the bot limited to the range.
+type Param struct {
+	Name  string
+	Value float64
+	Min   float64
+	Max   float64
+}
+
+// ParseParam returns the Param of the form "name=value:min:max".
+func ParseParam(s string) (Param, error) {
+	name, values, ok := strings.Cut(s, "=")
+	fields := strings.Split(values, ":")
+	if !ok || name == "" || len(fields) != 3 {
+		return Param{}, fmt.Errorf("invalid param %q, want name=value:min:max", s)
+	}
+
+	p := Param{Name: name}
+	for i, v := range []*float64{&p.Value, &p.Min, &p.Max} {
+		var err error
+		if *v, err = strconv.ParseFloat(fields[i], 64); err != nil {
+			return Param{}, fmt.Errorf("invalid param %q: %w", s, err)
+		}
+	}
+	if p.Min >= p.Max || p.Value < p.Min || p.Value > p.Max {
+		return Param{}, fmt.Errorf("invalid param %q, want min <= value <= max", s)
+	}
+
+	return p, nil
+}
+
+// TuneConfig holds the settings of the SPSA optimizer.
+// The steps are relative to the range of a parameter.
+type TuneConfig struct {
+	// BatchConfig is the batch played on every iteration.
+	BatchConfig
+	Iterations int
+	// A is the step size of the update, C is the size of the perturbation.
+	A float64
+	C float64
+	// Alpha and Gamma are the decay rates of the steps.
+	Alpha float64
+	Gamma float64
+}
+
+// DefaultTuneConfig returns the settings recommended by Spall with the CodinGame time limits.
+func DefaultTuneConfig() TuneConfig {
+	return TuneConfig{
+		BatchConfig: BatchConfig{Config: DefaultConfig(), Games: 16},
+		Iterations:  100,
+		A:           0.05,
+		C:           0.1,
+		Alpha:       0.602,
+		Gamma:       0.101,
+	}
+}
+
+// Tune optimizes the parameters of the bot by the simultaneous perturbation
+// stochastic approximation. On every iteration all the parameters are perturbed
+// at once in random directions, the bot with the values shifted up plays a batch
+// against the bot with the values shifted down, and the values move towards
+// the winner by the score of the batch. The progress is called after every iteration.
+func Tune(newReferee func() Referee, bot Bot, params []Param, conf TuneConfig, progress func(k int, params []Param, stats Stats)) ([]Param, error) {
+	rnd := rand.New(rand.NewSource(conf.Seed))
+	params = append([]Param(nil), params...)
+	// the values are optimized in the unit range
+	theta := make([]float64, len(params))
+	for i, p := range params {
+		theta[i] = (p.Value - p.Min) / (p.Max - p.Min)
+	}
+
+	delta := make([]float64, len(params))
+	plus := make([]Param, len(params))
+	minus := make([]Param, len(params))
+	// the stability constant of the step size, a tenth of the iterations
+	stability := float64(conf.Iterations) / 10
+
+	for k := 0; k < conf.Iterations; k++ {
+		ak := conf.A / math.Pow(float64(k+1)+stability, conf.Alpha)
+		ck := conf.C / math.Pow(float64(k+1), conf.Gamma)
+
+		for i, p := range params {
+			delta[i] = float64(2*rnd.Intn(2) - 1)
+			plus[i], minus[i] = p, p
+			plus[i].Value = p.Min + clamp(theta[i]+ck*delta[i], 0, 1)*(p.Max-p.Min)
+			minus[i].Value = p.Min + clamp(theta[i]-ck*delta[i], 0, 1)*(p.Max-p.Min)
+		}
+
+		batch := conf.BatchConfig
+		batch.Seed = conf.Seed + int64(k*batch.Games)
+		stats, err := PlayBatch(newReferee, withParams(bot, plus), withParams(bot, minus), batch)
+		if err != nil {
+			return nil, err
+		}
+
+		// the score of the minus bot is 1 - Score
+		diff := 2*stats.Score - 1
+		for i := range params {
+			theta[i] = clamp(theta[i]+ak*diff/(2*ck*delta[i]), 0, 1)
+			params[i].Value = params[i].Min + theta[i]*(params[i].Max-params[i].Min)
+		}
+
+		if progress != nil {
+			progress(k, params, stats)
+		}
+	}
+
+	return params, nil
+}
+
+// withParams returns the bot playing with the values of the parameters.
+func withParams(bot Bot, params []Param) Bot {
+	values := make([]string, len(params))
+	for i, p := range params {
+		values[i] = p.Name + "=" + strconv.FormatFloat(p.Value, 'g', -1, 64)
+	}
+	bot.Env = append(append([]string(nil), bot.Env...), ParamsEnv+"="+strings.Join(values, ","))
+	bot.Name += " " + strings.Join(values, " ")
+	return bot
+}
+
+// FormatParams returns the parameters as a block of Go constants,
+// the name "exp_decay" becomes "paramExpDecay".
+func FormatParams(params []Param) string {
+	var b strings.Builder
+	b.WriteString("const (\n")
+	for _, p := range params {
+		fmt.Fprintf(&b, "\t%s = %s\n", constName(p.Name), strconv.FormatFloat(p.Value, 'g', 6, 64))
+	}
+	b.WriteString(")\n")
+	return b.String()
+}
+
+// constName returns the camel case constant name of the parameter.
+func constName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var b strings.Builder
+	b.WriteString("param")
+	for _, w := range words {
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package arena
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseParam(t *testing.T) {
+	p, err := ParseParam("aim=0.5:0:2")
+	require.NoError(t, err)
+	assert.Equal(t, Param{Name: "aim", Value: 0.5, Min: 0, Max: 2}, p)
+
+	for _, s := range []string{"aim", "aim=0.5", "=0.5:0:2", "aim=x:0:2", "aim=3:0:2", "aim=1:2:0"} {
+		_, err = ParseParam(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestTune(t *testing.T) {
+	conf := DefaultTuneConfig()
+	conf.Seed = 1
+	conf.Games = 4
+	conf.Iterations = 30
+	conf.A = 0.1
+	newReferee := func() Referee { return &MockReferee{Turns: 5} }
+	params := []Param{{Name: "aim", Value: 0.3, Min: 0, Max: 2}}
+
+	var calls int
+	tuned, err := Tune(newReferee, testBot("aim"), params, conf, func(k int, ps []Param, stats Stats) {
+		assert.Equal(t, calls, k)
+		assert.Equal(t, 4, stats.Games)
+		calls++
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 30, calls)
+	assert.Equal(t, 0.3, params[0].Value)
+	assert.Less(t, math.Abs(tuned[0].Value-1), 0.2)
+}
+
+func TestWithParams(t *testing.T) {
+	bot := withParams(testBot("aim"), []Param{{Name: "aim", Value: 1.5}, {Name: "decay", Value: 2}})
+	assert.Equal(t, "aim aim=1.5 decay=2", bot.Name)
+	assert.Equal(t, []string{"ARENA_TEST_BOT=aim", "BOT_PARAMS=aim=1.5,decay=2"}, bot.Env)
+}
+
+func TestFormatParams(t *testing.T) {
+	params := []Param{{Name: "aim", Value: 1.0312345}, {Name: "exp_decay", Value: 2}}
+	assert.Equal(t, "const (\n\tparamAim = 1.03123\n\tparamExpDecay = 2\n)\n", FormatParams(params))
+}
@@ -0,0 +1,84 @@
+package main
+
+// Arena runs a local match between bot binaries and writes the result as JSON.
+//
+// Usage:
+//
+//	go run ./cmd/arena [-game mock] [-seed 1] [-replay replay.json] "./bot1" "./bot2 -flag"
+//	go run ./cmd/arena -games 200 [-parallel 8] "./new" "./old"
+//
+// Every argument is the command line of a bot.
+// With -games the two bots play a batch of seeded matches and the statistics are written instead.
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/mrsombre/codingame-framework/arena"
+)
+
+func main() {
+	conf := arena.DefaultConfig()
+	game := flag.String("game", "mock", "game of the referee")
+	flag.Int64Var(&conf.Seed, "seed", time.Now().UnixNano(), "seed of the match")
+	flag.DurationVar(&conf.FirstTurnTimeout, "first-timeout", conf.FirstTurnTimeout, "first turn timeout")
+	flag.DurationVar(&conf.TurnTimeout, "timeout", conf.TurnTimeout, "turn timeout")
+	replayPath := flag.String("replay", "", "file to write the replay to")
+	games := flag.Int("games", 1, "number of matches between two bots")
+	parallel := flag.Int("parallel", 0, "number of matches played at once, the number of CPUs by default")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("arena: ")
+
+	ref := arena.NewReferee(*game)
+	if ref == nil {
+		log.Fatalf("unknown game %q", *game)
+	}
+	if flag.NArg() == 0 {
+		log.Fatal("no bots")
+	}
+	bots := make([]arena.Bot, 0, flag.NArg())
+	for _, arg := range flag.Args() {
+		bots = append(bots, arena.ParseBot(arg))
+	}
+
+	if *games > 1 {
+		if len(bots) != 2 {
+			log.Fatal("a batch needs two bots")
+		}
+		batch := arena.BatchConfig{Config: conf, Games: *games, Parallel: *parallel}
+		stats, err := arena.PlayBatch(func() arena.Referee { return arena.NewReferee(*game) }, bots[0], bots[1], batch)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Print(stats)
+		writeJSON(stats)
+		return
+	}
+
+	replay, err := arena.Play(ref, bots, conf)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *replayPath != "" {
+		if err = replay.Save(*replayPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	writeJSON(replay.Result)
+}
+
+// writeJSON writes the value to stdout.
+func writeJSON(v any) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.Fatal(err)
+	}
+}
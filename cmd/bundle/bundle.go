@@ -0,0 +1,288 @@
+package main
+
+// Merging the source files of package main into a single file.
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// importSpec is an import of the bundle.
+type importSpec struct {
+	Name string
+	Path string
+}
+
+func (s importSpec) String() string {
+	if s.Name == "" {
+		return strconv.Quote(s.Path)
+	}
+	return s.Name + " " + strconv.Quote(s.Path)
+}
+
+// selectFiles returns the names of non-test Go files in the directory
+// matching the patterns and the build tags, all files if no patterns given.
+func selectFiles(dir string, patterns, tags []string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := build.Default
+	ctx.BuildTags = tags
+
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if !isSelected(name, patterns) {
+			continue
+		}
+		ok, err := ctx.MatchFile(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no files selected in %s", dir)
+	}
+
+	return names, nil
+}
+
+// isSelected tests if the file name matches any of the patterns.
+func isSelected(name string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFiles parses the files of the directory with comments.
+func parseFiles(fset *token.FileSet, dir string, names []string) ([]*ast.File, error) {
+	files := make([]*ast.File, 0, len(names))
+	for _, name := range names {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// importName returns the default name of the imported package.
+func importName(importPath string) string {
+	name := path.Base(importPath)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		if dir := path.Dir(importPath); dir != "." {
+			name = path.Base(dir)
+		}
+	}
+	return name
+}
+
+// mergeImports collects the imports of all files without duplicates.
+// A name imported from different paths is aliased in the later files.
+func mergeImports(files []*ast.File) []importSpec {
+	var specs []importSpec
+	names := declNames(files)
+	seen := make(map[importSpec]bool)
+
+	for _, file := range files {
+		for _, imp := range file.Imports {
+			importPath, _ := strconv.Unquote(imp.Path.Value)
+			spec := importSpec{Path: importPath}
+			name := importName(importPath)
+			if imp.Name != nil {
+				spec.Name = imp.Name.Name
+				name = spec.Name
+			}
+
+			if name != "_" && name != "." {
+				if p, ok := names[name]; ok && p != importPath {
+					alias := uniqueName(strings.ReplaceAll(importPath, "/", ""), names)
+					renamePackage(file, name, alias)
+					spec.Name = alias
+					name = alias
+				}
+				names[name] = importPath
+			}
+
+			if !seen[spec] {
+				seen[spec] = true
+				specs = append(specs, spec)
+			}
+		}
+	}
+
+	sort.Slice(specs, func(i, j int) bool {
+		if specs[i].Path != specs[j].Path {
+			return specs[i].Path < specs[j].Path
+		}
+		return specs[i].Name < specs[j].Name
+	})
+
+	return specs
+}
+
+// declNames returns the names of the package level declarations.
+func declNames(files []*ast.File) map[string]string {
+	names := make(map[string]string)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil {
+					names[decl.Name.Name] = ""
+				}
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						names[spec.Name.Name] = ""
+					case *ast.ValueSpec:
+						for _, id := range spec.Names {
+							names[id.Name] = ""
+						}
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+// uniqueName returns the name or the name with a numeric suffix not used yet.
+func uniqueName(name string, used map[string]string) string {
+	unique := name
+	for i := 2; ; i++ {
+		if _, ok := used[unique]; !ok {
+			return unique
+		}
+		unique = name + strconv.Itoa(i)
+	}
+}
+
+// renamePackage renames references to the imported package in the file.
+func renamePackage(file *ast.File, from, to string) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		// package names are never resolved by the parser
+		if id, ok := sel.X.(*ast.Ident); ok && id.Name == from && id.Obj == nil {
+			id.Name = to
+		}
+		return true
+	})
+}
+
+// fileBody returns the source of the file without the package clause and imports.
+func fileBody(fset *token.FileSet, file *ast.File) ([]byte, error) {
+	var skip []ast.Node
+	decls := file.Decls[:0:0]
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			skip = append(skip, gen)
+			continue
+		}
+		decls = append(decls, decl)
+	}
+
+	comments := file.Comments[:0:0]
+	for _, cg := range file.Comments {
+		if cg.End() < file.Package || isInside(cg, skip) {
+			continue
+		}
+		comments = append(comments, cg)
+	}
+
+	body := &ast.File{
+		Name:     file.Name,
+		Package:  file.Package,
+		Decls:    decls,
+		Comments: comments,
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, body); err != nil {
+		return nil, err
+	}
+
+	// cut the package clause
+	src := buf.Bytes()
+	if i := bytes.IndexByte(src, '\n'); i >= 0 {
+		src = src[i+1:]
+	}
+
+	return bytes.TrimSpace(src), nil
+}
+
+// isInside tests if the node is located inside any of the nodes.
+func isInside(n ast.Node, nodes []ast.Node) bool {
+	for _, t := range nodes {
+		if n.Pos() >= t.Pos() && n.End() <= t.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// bundle merges the files of package main into a single formatted source.
+func bundle(fset *token.FileSet, files []*ast.File) ([]byte, error) {
+	var buf bytes.Buffer
+
+	buf.WriteString("// Code generated by bundle. DO NOT EDIT.\n\n")
+	buf.WriteString("package main\n")
+
+	specs := mergeImports(files)
+	if len(specs) > 0 {
+		buf.WriteString("\nimport (\n")
+		for _, spec := range specs {
+			buf.WriteString("\t" + spec.String() + "\n")
+		}
+		buf.WriteString(")\n")
+	}
+
+	for _, file := range files {
+		if file.Name.Name != "main" {
+			return nil, fmt.Errorf("%s: package %s is not main", fset.File(file.Package).Name(), file.Name.Name)
+		}
+		body, err := fileBody(fset, file)
+		if err != nil {
+			return nil, err
+		}
+		if len(body) == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "\n// %s\n\n", filepath.Base(fset.File(file.Package).Name()))
+		buf.Write(body)
+		buf.WriteString("\n")
+	}
+
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var bundleTests = map[string]string{
+	"a.go": `// Package doc is dropped.
+package main
+
+// File comment is kept.
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// A is a doc comment.
+func A() int {
+	fmt.Println("a")
+	return rand.Int()
+}
+`,
+	"b.go": `package main
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+func B() {
+	b := make([]byte, 1)
+	rand.Read(b)
+	fmt.Println(b)
+}
+`,
+	"c_release.go": `//go:build release
+
+package main
+
+const release = true
+`,
+	"c_local.go": `//go:build !release
+
+package main
+
+const release = false
+`,
+	"main.go": `package main
+
+func main() {
+	A()
+	B()
+}
+`,
+	"main_test.go": `package main
+`,
+}
+
+func writeBundleTests(t *testing.T) string {
+	dir := t.TempDir()
+	for name, src := range bundleTests {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644))
+	}
+	return dir
+}
+
+func TestSelectFiles(t *testing.T) {
+	dir := writeBundleTests(t)
+
+	names, err := selectFiles(dir, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "b.go", "c_local.go", "main.go"}, names)
+
+	names, err = selectFiles(dir, nil, []string{"release"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "b.go", "c_release.go", "main.go"}, names)
+
+	names, err = selectFiles(dir, []string{"a.go", "m*.go"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "main.go"}, names)
+
+	_, err = selectFiles(dir, []string{"x.go"}, nil)
+	assert.Error(t, err)
+}
+
+func TestImportName(t *testing.T) {
+	assert.Equal(t, "fmt", importName("fmt"))
+	assert.Equal(t, "rand", importName("math/rand"))
+	assert.Equal(t, "rand", importName("math/rand/v2"))
+}
+
+func TestBundle(t *testing.T) {
+	dir := writeBundleTests(t)
+	names, err := selectFiles(dir, nil, []string{"release"})
+	require.NoError(t, err)
+
+	fset := token.NewFileSet()
+	files, err := parseFiles(fset, dir, names)
+	require.NoError(t, err)
+
+	src, err := bundle(fset, files)
+	require.NoError(t, err)
+
+	want := `// Code generated by bundle. DO NOT EDIT.
+
+package main
+
+import (
+	cryptorand "crypto/rand"
+	"fmt"
+	"math/rand"
+)
+
+// a.go
+
+// File comment is kept.
+
+// A is a doc comment.
+func A() int {
+	fmt.Println("a")
+	return rand.Int()
+}
+
+// b.go
+
+func B() {
+	b := make([]byte, 1)
+	cryptorand.Read(b)
+	fmt.Println(b)
+}
+
+// c_release.go
+
+const release = true
+
+// main.go
+
+func main() {
+	A()
+	B()
+}
+`
+	assert.Equal(t, want, string(src))
+}
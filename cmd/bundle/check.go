@@ -0,0 +1,30 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/token"
+	"go/types"
+)
+
+// typeCheck type-checks the bundle file as package main,
+// the collected info is returned even if the check fails.
+func typeCheck(fset *token.FileSet, file *ast.File, conf types.Config) (*types.Package, *types.Info, error) {
+	return typeCheckFiles(fset, []*ast.File{file}, conf)
+}
+
+// typeCheckFiles is the typeCheck of the files of one package.
+func typeCheckFiles(fset *token.FileSet, files []*ast.File, conf types.Config) (*types.Package, *types.Info, error) {
+	if conf.Importer == nil {
+		conf.Importer = importer.Default()
+	}
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	pkg, err := conf.Check("main", fset, files, info)
+	return pkg, info, err
+}
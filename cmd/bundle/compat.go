@@ -0,0 +1,260 @@
+package main
+
+// Checking the bundle against an older Go version used by CodinGame.
+// Language features are checked by go/types, standard library symbols
+// are checked against the API lists of the Go distribution ($GOROOT/api/go1.*.txt).
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// goVersion is a minor version of Go 1.x.
+type goVersion int
+
+func (v goVersion) String() string {
+	return "go1." + strconv.Itoa(int(v))
+}
+
+// parseGoVersion parses the version in the form 1.18 or go1.18.
+func parseGoVersion(s string) (goVersion, error) {
+	minor := strings.TrimPrefix(strings.TrimPrefix(s, "go"), "1.")
+	if i := strings.IndexByte(minor, '.'); i >= 0 {
+		minor = minor[:i]
+	}
+	v, err := strconv.Atoi(minor)
+	if err != nil || !strings.Contains(s, "1.") {
+		return 0, fmt.Errorf("invalid go version %q", s)
+	}
+	return goVersion(v), nil
+}
+
+// apiIndex maps the standard library symbols to the version they were added in.
+// Keys are "path.Name" for package symbols, "path.Type.Name" for methods and fields,
+// and "path" for packages.
+type apiIndex map[string]goVersion
+
+// add records the key if it is not known from an earlier version.
+func (idx apiIndex) add(key string, v goVersion) {
+	if old, ok := idx[key]; !ok || v < old {
+		idx[key] = v
+	}
+}
+
+// loadAPI reads the API lists of the directory.
+func loadAPI(dir string) (apiIndex, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "go1*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no api files in %s", dir)
+	}
+
+	idx := make(apiIndex)
+	for _, path := range paths {
+		v := goVersion(0)
+		if name := strings.TrimSuffix(filepath.Base(path), ".txt"); name != "go1" {
+			if v, err = parseGoVersion(name); err != nil {
+				return nil, err
+			}
+		}
+		if err = idx.read(path, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return idx, nil
+}
+
+// read adds the symbols of the API file.
+func (idx apiIndex) read(path string, v goVersion) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		pkg, key, ok := parseAPILine(s.Text())
+		if !ok {
+			continue
+		}
+		idx.add(pkg, v)
+		idx.add(pkg+"."+key, v)
+	}
+
+	return s.Err()
+}
+
+// parseAPILine returns the package path and the symbol key of the API line, like
+// "pkg strings, func CutPrefix(string, string) (string, bool) #42537".
+func parseAPILine(line string) (string, string, bool) {
+	if !strings.HasPrefix(line, "pkg ") {
+		return "", "", false
+	}
+	pkg, decl, ok := strings.Cut(line[len("pkg "):], ", ")
+	if !ok {
+		return "", "", false
+	}
+	// platform specific symbols, like "pkg syscall (linux-386)"
+	pkg, _, _ = strings.Cut(pkg, " ")
+
+	kind, rest, _ := strings.Cut(decl, " ")
+	switch kind {
+	case "func", "const", "var":
+		return pkg, symbolName(rest), true
+	case "method":
+		// method (*T) Name(...)
+		recv, name, _ := strings.Cut(rest, ") ")
+		recv = strings.TrimLeft(recv, "(*")
+		return pkg, symbolName(recv) + "." + symbolName(name), true
+	case "type":
+		// type T struct, Field T or type T interface, Method(...)
+		name, member, ok := strings.Cut(rest, ", ")
+		key := symbolName(name)
+		if ok {
+			key += "." + symbolName(member)
+		}
+		return pkg, key, true
+	}
+
+	return "", "", false
+}
+
+// symbolName returns the leading identifier of the declaration.
+func symbolName(s string) string {
+	end := strings.IndexAny(s, " ([,")
+	if end < 0 {
+		return s
+	}
+	return s[:end]
+}
+
+// compatIssue is a use of a feature not available in the target version.
+type compatIssue struct {
+	Pos token.Position
+	Msg string
+}
+
+func (i compatIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Pos, i.Msg)
+}
+
+// checkCompat reports the language features and standard library symbols
+// of the source not available in the target Go version, at the positions of the named file.
+func checkCompat(src []byte, name string, target goVersion, api apiIndex) ([]compatIssue, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	return checkFiles(fset, []*ast.File{file}, types.Config{}, target, api), nil
+}
+
+// checkFiles is the checkCompat of the files of one package type checked with the config,
+// the imports not resolved by the importer of the config are reported as issues too.
+func checkFiles(fset *token.FileSet, files []*ast.File, conf types.Config, target goVersion, api apiIndex) []compatIssue {
+	var issues []compatIssue
+	conf.GoVersion = target.String()
+	conf.Error = func(err error) {
+		if terr, ok := err.(types.Error); ok {
+			issues = append(issues, compatIssue{fset.Position(terr.Pos), terr.Msg})
+		}
+	}
+	// type errors are reported as issues
+	_, info, _ := typeCheckFiles(fset, files, conf)
+
+	report := func(pos token.Pos, key string) {
+		if v, ok := api[key]; ok && v > target {
+			issues = append(issues, compatIssue{fset.Position(pos), fmt.Sprintf("%s requires %s", key, v)})
+		}
+	}
+
+	for _, file := range files {
+		checkSymbols(file, info, report)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i].Pos, issues[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		return a.Offset < b.Offset
+	})
+
+	return issues
+}
+
+// checkSymbols reports the imported packages and the standard library symbols used by the file.
+func checkSymbols(file *ast.File, info *types.Info, report func(pos token.Pos, key string)) {
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		report(spec.Pos(), path)
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if sel, ok := info.Selections[n]; ok {
+				if key := selectionKey(sel); key != "" {
+					report(n.Sel.Pos(), key)
+				}
+				return true
+			}
+			if obj := info.Uses[n.Sel]; obj != nil && obj.Pkg() != nil && obj.Pkg().Path() != "main" {
+				report(n.Sel.Pos(), obj.Pkg().Path()+"."+obj.Name())
+			}
+		}
+		return true
+	})
+}
+
+// selectionKey returns the API key of the standard library method or field.
+func selectionKey(sel *types.Selection) string {
+	obj := sel.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() == "main" {
+		return ""
+	}
+
+	var t types.Type
+	switch obj := obj.(type) {
+	case *types.Func:
+		t = obj.Type().(*types.Signature).Recv().Type()
+	case *types.Var:
+		// the struct declaring the field is the last one on the embedding path
+		t = sel.Recv()
+		index := sel.Index()
+		for _, i := range index[:len(index)-1] {
+			st, ok := derefType(t).Underlying().(*types.Struct)
+			if !ok {
+				return ""
+			}
+			t = st.Field(i).Type()
+		}
+	}
+
+	named, ok := derefType(t).(*types.Named)
+	if !ok {
+		return ""
+	}
+	return obj.Pkg().Path() + "." + named.Obj().Name() + "." + obj.Name()
+}
+
+// derefType returns the element type of a pointer type.
+func derefType(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
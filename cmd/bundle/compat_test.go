@@ -0,0 +1,182 @@
+package main
+
+import (
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoVersion(t *testing.T) {
+	for _, s := range []string{"1.18", "go1.18", "1.18.3"} {
+		v, err := parseGoVersion(s)
+		require.NoError(t, err)
+		assert.Equal(t, goVersion(18), v)
+	}
+	_, err := parseGoVersion("x")
+	assert.Error(t, err)
+	assert.Equal(t, "go1.21", goVersion(21).String())
+}
+
+func TestParseAPILine(t *testing.T) {
+	tests := []struct {
+		line string
+		pkg  string
+		key  string
+	}{
+		{"pkg strings, func CutPrefix(string, string) (string, bool) #42537", "strings", "CutPrefix"},
+		{"pkg slices, func Max[$0 interface{ ~[]$1 }, $1 cmp.Ordered]($0) $1 #60091", "slices", "Max"},
+		{"pkg math/rand, method (*Rand) Float32() float32", "math/rand", "Rand.Float32"},
+		{"pkg archive/tar, type Header struct, Format Format", "archive/tar", "Header.Format"},
+		{"pkg crypto/ecdh, type Curve interface, GenerateKey(io.Reader) (*PrivateKey, error) #52221", "crypto/ecdh", "Curve.GenerateKey"},
+		{"pkg runtime/cgo (linux-386-cgo), type Incomplete struct #46731", "runtime/cgo", "Incomplete"},
+		{"pkg debug/elf, const R_LARCH_32_PCREL = 99 #54222", "debug/elf", "R_LARCH_32_PCREL"},
+		{"pkg archive/zip, var ErrInsecurePath error #55356", "archive/zip", "ErrInsecurePath"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.line, func(t *testing.T) {
+			pkg, key, ok := parseAPILine(tc.line)
+			assert.True(t, ok)
+			assert.Equal(t, tc.pkg, pkg)
+			assert.Equal(t, tc.key, key)
+		})
+	}
+
+	_, _, ok := parseAPILine("# comment")
+	assert.False(t, ok)
+}
+
+var compatAPITests = map[string]string{
+	"go1.txt": `pkg strings, func HasPrefix(string, string) bool
+pkg time, method (Time) Unix() int64
+`,
+	"go1.10.txt": `pkg strings, type Builder struct
+pkg strings, method (*Builder) String() string
+`,
+	"go1.20.txt": `pkg strings, func CutPrefix(string, string) (string, bool) #42537
+pkg time, method (Time) Compare(Time) int #50770
+`,
+	"go1.21.txt": `pkg slices, func Max[$0 interface{ ~[]$1 }, $1 cmp.Ordered]($0) $1 #60091
+`,
+}
+
+var compatTests = `package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+func main() {
+	var b strings.Builder
+	s, _ := strings.CutPrefix("ab", "a")
+	now := time.Now()
+	fmt.Println(b.String(), s, strings.HasPrefix(s, "b"), now.Unix(), now.Compare(now))
+	fmt.Println(slices.Max([]int{1, 2}), min(1, 2))
+}
+`
+
+func TestCheckCompat(t *testing.T) {
+	dir := t.TempDir()
+	for name, src := range compatAPITests {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644))
+	}
+	api, err := loadAPI(dir)
+	require.NoError(t, err)
+	assert.Equal(t, goVersion(10), api["strings.Builder.String"])
+	assert.Equal(t, goVersion(21), api["slices"])
+
+	issues, err := checkCompat([]byte(compatTests), "bundle.go", 18, api)
+	require.NoError(t, err)
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	want := []string{
+		"bundle.go:5:2: slices requires go1.21",
+		"bundle.go:12:18: strings.CutPrefix requires go1.20",
+		"bundle.go:14:72: time.Time.Compare requires go1.20",
+		"bundle.go:15:21: slices.Max requires go1.21",
+		"bundle.go:15:39: built-in min requires go1.21 or later",
+	}
+	assert.Equal(t, want, got)
+
+	issues, err = checkCompat([]byte(compatTests), "bundle.go", 21, api)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+// TestModuleCompat checks all the packages of the module, including the tests
+// the bundle never contains, against the Go version of go.mod.
+func TestModuleCompat(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type checks the dependencies from source")
+	}
+	root := filepath.Join("..", "..")
+	mod, err := os.ReadFile(filepath.Join(root, "go.mod"))
+	require.NoError(t, err)
+	var target goVersion
+	for _, line := range strings.Split(string(mod), "\n") {
+		if strings.HasPrefix(line, "go ") {
+			target, err = parseGoVersion(strings.TrimPrefix(line, "go "))
+			require.NoError(t, err)
+		}
+	}
+	require.NotZero(t, target)
+
+	api, err := loadAPI(filepath.Join(build.Default.GOROOT, "api"))
+	if err != nil {
+		t.Skip(err)
+	}
+
+	// the files of a directory by the package name, the external tests are a package of their own
+	packages := make(map[string][]*ast.File)
+	fset := token.NewFileSet()
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != root && (name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+		if ok, err := build.Default.MatchFile(filepath.Dir(path), d.Name()); err != nil || !ok {
+			return err
+		}
+
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return err
+		}
+		key := filepath.Dir(path) + " " + file.Name.Name
+		packages[key] = append(packages[key], file)
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, packages)
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	for key, files := range packages {
+		for _, issue := range checkFiles(fset, files, conf, target, api) {
+			t.Errorf("%s: %s", key, issue)
+		}
+	}
+}
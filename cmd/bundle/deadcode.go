@@ -0,0 +1,501 @@
+package main
+
+// Removing package level declarations unreachable from main and init.
+// Methods are kept while their type is reachable and the method is called directly
+// or may be called dynamically through an interface with the same method name.
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// dynamicMethods are called by the standard library through interfaces
+// not visible in the bundle, like fmt.Stringer or sort.Interface.
+var dynamicMethods = []string{
+	"String", "GoString", "Format", "Error", "Unwrap", "Is", "As",
+	"MarshalJSON", "UnmarshalJSON", "MarshalText", "UnmarshalText",
+	"Len", "Less", "Swap", "Push", "Pop",
+	"Read", "Write", "Close", "Scan",
+}
+
+// declUnit is a removable part of the source: a function, a type or a value specification.
+// Constant groups using iota are a single unit, as removing a part shifts the values.
+type declUnit struct {
+	nodes []ast.Node
+	objs  []types.Object
+	// recv is the receiver type name of a method
+	recv *types.TypeName
+	root bool
+	live bool
+}
+
+// name returns the kind and the name of the unit for the report.
+func (u *declUnit) name() string {
+	obj := u.objs[0]
+	switch obj := obj.(type) {
+	case *types.Func:
+		if u.recv != nil {
+			return fmt.Sprintf("method %s.%s", u.recv.Name(), obj.Name())
+		}
+		return "func " + obj.Name()
+	case *types.TypeName:
+		return "type " + obj.Name()
+	case *types.Const:
+		return "const " + obj.Name()
+	default:
+		return "var " + obj.Name()
+	}
+}
+
+// deadCode holds the state of the reachability analysis.
+type deadCode struct {
+	fset  *token.FileSet
+	file  *ast.File
+	pkg   *types.Package
+	info  *types.Info
+	units []*declUnit
+	byObj map[types.Object]*declUnit
+	// iface are the method names callable through interfaces
+	iface map[string]bool
+}
+
+// origin returns the generic origin of the instantiated object.
+func origin(obj types.Object) types.Object {
+	switch obj := obj.(type) {
+	case *types.Func:
+		return obj.Origin()
+	case *types.Var:
+		return obj.Origin()
+	}
+	return obj
+}
+
+// addUnit registers the unit for its objects.
+func (dc *deadCode) addUnit(u *declUnit) {
+	dc.units = append(dc.units, u)
+	for _, obj := range u.objs {
+		dc.byObj[obj] = u
+	}
+}
+
+// collect splits the file declarations into units.
+func (dc *deadCode) collect() {
+	for _, decl := range dc.file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			u := &declUnit{
+				nodes: []ast.Node{decl},
+				objs:  []types.Object{dc.info.Defs[decl.Name]},
+			}
+			if decl.Recv != nil {
+				u.recv = receiverType(dc.info.Defs[decl.Name].(*types.Func))
+			} else if decl.Name.Name == "main" || decl.Name.Name == "init" {
+				u.root = true
+			}
+			dc.addUnit(u)
+		case *ast.GenDecl:
+			if decl.Tok == token.IMPORT {
+				continue
+			}
+			if decl.Tok == token.CONST && dc.isIotaGroup(decl) {
+				u := &declUnit{}
+				for _, spec := range decl.Specs {
+					u.nodes = append(u.nodes, spec)
+					u.objs = append(u.objs, dc.specObjects(spec)...)
+				}
+				dc.addUnit(u)
+				continue
+			}
+			for _, spec := range decl.Specs {
+				u := &declUnit{
+					nodes: []ast.Node{spec},
+					objs:  dc.specObjects(spec),
+				}
+				if vs, ok := spec.(*ast.ValueSpec); ok && decl.Tok == token.VAR {
+					u.root = dc.hasSideEffects(vs)
+				}
+				dc.addUnit(u)
+			}
+		}
+	}
+}
+
+// receiverType returns the type name of the method receiver.
+func receiverType(fn *types.Func) *types.TypeName {
+	t := fn.Type().(*types.Signature).Recv().Type()
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	if named, ok := t.(*types.Named); ok {
+		return named.Obj()
+	}
+	return nil
+}
+
+// specObjects returns the objects defined by the specification, blank names included.
+func (dc *deadCode) specObjects(spec ast.Spec) []types.Object {
+	switch spec := spec.(type) {
+	case *ast.TypeSpec:
+		return []types.Object{dc.info.Defs[spec.Name]}
+	case *ast.ValueSpec:
+		objs := make([]types.Object, 0, len(spec.Names))
+		for _, id := range spec.Names {
+			if obj := dc.info.Defs[id]; obj != nil {
+				objs = append(objs, obj)
+			} else {
+				// blank identifiers have no object
+				objs = append(objs, types.NewVar(id.Pos(), dc.pkg, id.Name, nil))
+			}
+		}
+		return objs
+	}
+	return nil
+}
+
+// isIotaGroup tests if the constant declaration uses iota or implicit repetition.
+func (dc *deadCode) isIotaGroup(decl *ast.GenDecl) bool {
+	iota := types.Universe.Lookup("iota")
+	for _, spec := range decl.Specs {
+		vs := spec.(*ast.ValueSpec)
+		if len(vs.Values) == 0 {
+			return true
+		}
+		found := false
+		ast.Inspect(vs, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && dc.info.Uses[id] == iota {
+				found = true
+			}
+			return !found
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSideEffects tests if the variable is blank or its initialization calls a function.
+func (dc *deadCode) hasSideEffects(vs *ast.ValueSpec) bool {
+	for _, id := range vs.Names {
+		if id.Name == "_" {
+			return true
+		}
+	}
+
+	found := false
+	for _, value := range vs.Values {
+		ast.Inspect(value, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return !found
+			}
+			tv := dc.info.Types[call.Fun]
+			if !tv.IsType() && !tv.IsBuiltin() {
+				found = true
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// mark marks the unit live and all units it references.
+func (dc *deadCode) mark(u *declUnit) {
+	if u.live {
+		return
+	}
+	u.live = true
+
+	for _, obj := range u.objs {
+		if tn, ok := obj.(*types.TypeName); ok {
+			dc.addInterface(tn.Type())
+		}
+	}
+	for _, node := range u.nodes {
+		ast.Inspect(node, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.InterfaceType:
+				dc.addInterface(dc.info.Types[n].Type)
+			case *ast.Ident:
+				if obj := dc.info.Uses[n]; obj != nil {
+					if ref, ok := dc.byObj[origin(obj)]; ok {
+						dc.mark(ref)
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+// addInterface records the method names of the interface type.
+func (dc *deadCode) addInterface(t types.Type) {
+	if t == nil {
+		return
+	}
+	iface, ok := t.Underlying().(*types.Interface)
+	if !ok {
+		return
+	}
+	for i := 0; i < iface.NumMethods(); i++ {
+		dc.iface[iface.Method(i).Name()] = true
+	}
+}
+
+// analyze marks the units reachable from the roots.
+func (dc *deadCode) analyze() {
+	for _, u := range dc.units {
+		if u.root {
+			dc.mark(u)
+		}
+	}
+
+	// methods of live types may be reached through interfaces
+	for changed := true; changed; {
+		changed = false
+		for _, u := range dc.units {
+			if u.live || u.recv == nil {
+				continue
+			}
+			if ru, ok := dc.byObj[u.recv]; ok && ru.live && dc.iface[u.objs[0].Name()] {
+				dc.mark(u)
+				changed = true
+			}
+		}
+	}
+}
+
+// remove drops the dead units and their comments from the file, returning the report.
+func (dc *deadCode) remove() []string {
+	dead := make(map[ast.Node]bool)
+	var removed []string
+	for _, u := range dc.units {
+		if u.live {
+			continue
+		}
+		removed = append(removed, u.name())
+		for _, node := range u.nodes {
+			dead[node] = true
+		}
+	}
+
+	var ranges []ast.Node
+	decls := dc.file.Decls[:0]
+	for _, decl := range dc.file.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if dead[decl] {
+				ranges = append(ranges, withDoc(decl, decl.Doc, nil))
+				continue
+			}
+		case *ast.GenDecl:
+			declRange := withDoc(decl, decl.Doc, nil)
+			specs := decl.Specs[:0]
+			for _, spec := range decl.Specs {
+				if !dead[spec] {
+					specs = append(specs, spec)
+					continue
+				}
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					ranges = append(ranges, withDoc(spec, spec.Doc, spec.Comment))
+				case *ast.ValueSpec:
+					ranges = append(ranges, withDoc(spec, spec.Doc, spec.Comment))
+				}
+			}
+			decl.Specs = specs
+			if len(specs) == 0 && decl.Tok != token.IMPORT {
+				ranges = append(ranges, declRange)
+				continue
+			}
+		}
+		decls = append(decls, decl)
+	}
+	dc.file.Decls = decls
+
+	comments := dc.file.Comments[:0]
+	for _, cg := range dc.file.Comments {
+		if !isInside(cg, ranges) {
+			comments = append(comments, cg)
+		}
+	}
+	dc.file.Comments = comments
+
+	dc.removeImports()
+	dc.removeEmptyFiles()
+
+	sort.Strings(removed)
+	return removed
+}
+
+// removeEmptyFiles drops the file name comments of the bundle left without declarations
+// together with the floating comments of the file.
+func (dc *deadCode) removeEmptyFiles() {
+	var marks []*ast.CommentGroup
+	for _, cg := range dc.file.Comments {
+		if isFileMark(cg) {
+			marks = append(marks, cg)
+		}
+	}
+
+	var ranges []ast.Node
+	for i, mark := range marks {
+		end := dc.file.End()
+		if i+1 < len(marks) {
+			end = marks[i+1].Pos() - 1
+		}
+		empty := true
+		for _, decl := range dc.file.Decls {
+			if decl.Pos() > mark.End() && decl.Pos() < end {
+				empty = false
+				break
+			}
+		}
+		if empty {
+			ranges = append(ranges, nodeRange{mark.Pos(), end})
+		}
+	}
+
+	comments := dc.file.Comments[:0]
+	for _, cg := range dc.file.Comments {
+		if !isInside(cg, ranges) {
+			comments = append(comments, cg)
+		}
+	}
+	dc.file.Comments = comments
+}
+
+// isFileMark tests if the comment is the file name written by the bundle.
+func isFileMark(cg *ast.CommentGroup) bool {
+	if len(cg.List) != 1 {
+		return false
+	}
+	text := cg.List[0].Text
+	return strings.HasPrefix(text, "// ") && strings.HasSuffix(text, ".go") && !strings.Contains(text[3:], " ")
+}
+
+// compactImports removes the blank lines left in the import block by removed imports.
+func compactImports(src []byte) []byte {
+	from := bytes.Index(src, []byte("\nimport (\n"))
+	if from < 0 {
+		return src
+	}
+	from += len("\nimport (\n")
+	to := from + bytes.Index(src[from:], []byte("\n)\n"))
+
+	block := bytes.ReplaceAll(src[from:to], []byte("\n\n"), []byte("\n"))
+	out := make([]byte, 0, len(src))
+	out = append(out, src[:from]...)
+	out = append(out, block...)
+	return append(out, src[to:]...)
+}
+
+// removeImports drops the imports no longer used by the file.
+func (dc *deadCode) removeImports() {
+	used := make(map[types.Object]bool)
+	ast.Inspect(dc.file, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if pn, ok := dc.info.Uses[id].(*types.PkgName); ok {
+				used[pn] = true
+			}
+		}
+		return true
+	})
+
+	decls := dc.file.Decls[:0]
+	for _, decl := range dc.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			decls = append(decls, decl)
+			continue
+		}
+		specs := gen.Specs[:0]
+		for _, spec := range gen.Specs {
+			is := spec.(*ast.ImportSpec)
+			if is.Name != nil && (is.Name.Name == "_" || is.Name.Name == ".") {
+				specs = append(specs, spec)
+				continue
+			}
+			obj := dc.info.Implicits[is]
+			if is.Name != nil {
+				obj = dc.info.Defs[is.Name]
+			}
+			if used[obj] {
+				specs = append(specs, spec)
+			}
+		}
+		gen.Specs = specs
+		if len(specs) > 0 {
+			decls = append(decls, decl)
+		}
+	}
+	dc.file.Decls = decls
+}
+
+// nodeRange is a source range of a node with its comments.
+type nodeRange struct {
+	pos, end token.Pos
+}
+
+func (r nodeRange) Pos() token.Pos {
+	return r.pos
+}
+
+func (r nodeRange) End() token.Pos {
+	return r.end
+}
+
+// withDoc returns the range of the node extended by the doc and line comments.
+func withDoc(node ast.Node, doc, comment *ast.CommentGroup) ast.Node {
+	r := nodeRange{node.Pos(), node.End()}
+	if doc != nil {
+		r.pos = doc.Pos()
+	}
+	if comment != nil {
+		r.end = comment.End()
+	}
+	return r
+}
+
+// eliminateDeadCode removes the declarations unreachable from main and init,
+// returning the source and the names of removed declarations.
+func eliminateDeadCode(src []byte) ([]byte, []string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "bundle.go", src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+	pkg, info, err := typeCheck(fset, file, types.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dc := &deadCode{
+		fset:  fset,
+		file:  file,
+		pkg:   pkg,
+		info:  info,
+		byObj: make(map[types.Object]*declUnit),
+		iface: make(map[string]bool),
+	}
+	for _, name := range dynamicMethods {
+		dc.iface[name] = true
+	}
+	dc.collect()
+	dc.analyze()
+	removed := dc.remove()
+
+	var buf bytes.Buffer
+	if err = format.Node(&buf, fset, file); err != nil {
+		return nil, nil, err
+	}
+
+	return compactImports(buf.Bytes()), removed, nil
+}
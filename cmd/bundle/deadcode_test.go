@@ -0,0 +1,118 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var deadCodeTests = `package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// a.go
+
+const (
+	zero = iota
+	one
+)
+
+const (
+	used   = 1
+	unused = 2
+)
+
+var registry = newRegistry()
+
+type Shape interface {
+	Area() float64
+}
+
+type Square struct {
+	Side float64
+}
+
+func (s Square) Area() float64 {
+	return s.Side * s.Side
+}
+
+// Perimeter is not called.
+func (s Square) Perimeter() float64 {
+	return 4 * s.Side
+}
+
+func (s Square) String() string {
+	return fmt.Sprint(s.Side)
+}
+
+type Stack[T any] struct {
+	items []T
+}
+
+func (s *Stack[T]) Push(v T) {
+	s.items = append(s.items, v)
+}
+
+func (s *Stack[T]) Peek() T {
+	return s.items[len(s.items)-1]
+}
+
+// b.go
+
+// Sorted is not used.
+func Sorted(a []int) []int {
+	sort.Ints(a)
+	return a
+}
+
+func newRegistry() map[string]int {
+	return map[string]int{}
+}
+
+func main() {
+	var shape Shape = Square{one}
+	fmt.Println(shape.Area(), used, registry)
+	s := &Stack[int]{}
+	s.Push(1)
+}
+`
+
+func TestEliminateDeadCode(t *testing.T) {
+	src, removed, err := eliminateDeadCode([]byte(deadCodeTests))
+	require.NoError(t, err)
+
+	want := []string{
+		"const unused",
+		"func Sorted",
+		"method Square.Perimeter",
+		"method Stack.Peek",
+	}
+	assert.Equal(t, want, removed)
+
+	out := string(src)
+	assert.NotContains(t, out, `"sort"`)
+	assert.NotContains(t, out, "Perimeter")
+	assert.NotContains(t, out, "// b.go\n\n// Sorted")
+	assert.Contains(t, out, "// b.go\n\nfunc newRegistry()")
+	assert.Contains(t, out, "zero = iota")
+	assert.Contains(t, out, "func (s Square) String() string")
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "bundle.go", src, parser.ParseComments)
+	require.NoError(t, err)
+	_, _, err = typeCheck(fset, file, types.Config{})
+	assert.NoError(t, err)
+}
+
+func TestCompactImports(t *testing.T) {
+	src := "package main\n\nimport (\n\t\"fmt\"\n\n\t\"os\"\n)\n\nfunc main() {\n\n}\n"
+	want := "package main\n\nimport (\n\t\"fmt\"\n\t\"os\"\n)\n\nfunc main() {\n\n}\n"
+	assert.Equal(t, want, string(compactImports([]byte(src))))
+}
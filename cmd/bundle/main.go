@@ -0,0 +1,124 @@
+package main
+
+// Bundle merges the non-test Go files of package main into a single file
+// for the CodinGame submission.
+//
+// Usage:
+//
+//	go run ./cmd/bundle [-dir .] [-tags release] [-dce=false] [-go 1.18] [-minify [-rename]] [-o bundle.go] [patterns...]
+//
+// Patterns select the files of the directory, all files are bundled by default.
+// Declarations unreachable from main and init are removed unless -dce=false.
+// With -go the bundle is checked against the language version and the standard library API
+// of the older Go used by CodinGame. On failure the checked bundle is written next to the output
+// with the .rej suffix (bundle.go.rej for stdout) and the issues refer to its positions.
+// With -minify comments and whitespace are stripped, -rename also shortens unexported identifiers.
+
+import (
+	"flag"
+	"go/build"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "source directory of package main")
+	tags := flag.String("tags", "", "comma separated build tags")
+	output := flag.String("o", "", "output file, stdout by default")
+	dce := flag.Bool("dce", true, "remove declarations unreachable from main")
+	minified := flag.Bool("minify", false, "remove comments and redundant whitespace")
+	rename := flag.Bool("rename", false, "shorten unexported identifiers when minifying")
+	goVer := flag.String("go", "", "check compatibility with the Go version, like 1.18")
+	apiDir := flag.String("api", filepath.Join(build.Default.GOROOT, "api"), "directory of the Go API lists")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("bundle: ")
+
+	var buildTags []string
+	if *tags != "" {
+		buildTags = strings.Split(*tags, ",")
+	}
+
+	names, err := selectFiles(*dir, flag.Args(), buildTags)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	files, err := parseFiles(fset, *dir, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := bundle(fset, files)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *dce {
+		size := len(src)
+		var removed []string
+		src, removed, err = eliminateDeadCode(src)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, name := range removed {
+			log.Printf("removed %s", name)
+		}
+		log.Printf("%d declarations removed, %d -> %d bytes", len(removed), size, len(src))
+	}
+
+	if *goVer != "" {
+		target, err := parseGoVersion(*goVer)
+		if err != nil {
+			log.Fatal(err)
+		}
+		api, err := loadAPI(*apiDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		rejected := rejectedName(*output)
+		issues, err := checkCompat(src, rejected, target, api)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, issue := range issues {
+			log.Print(issue)
+		}
+		if len(issues) > 0 {
+			if err = os.WriteFile(rejected, src, 0o644); err != nil {
+				log.Fatal(err)
+			}
+			log.Fatalf("%d issues with %s, the checked bundle is written to %s", len(issues), target, rejected)
+		}
+	}
+
+	if *minified {
+		size := len(src)
+		if src, err = minify(src, *rename); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("minified %d -> %d bytes", size, len(src))
+	}
+
+	if *output == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err = os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("%d files, %d bytes written to %s", len(files), len(src), *output)
+}
+
+// rejectedName returns the file name of the bundle failing the checks.
+func rejectedName(output string) string {
+	if output == "" {
+		return "bundle.go.rej"
+	}
+	return output + ".rej"
+}
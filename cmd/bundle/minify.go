@@ -0,0 +1,253 @@
+package main
+
+// Minification of the bundle to fit the submission size limit.
+// Comments and redundant whitespace are removed, unexported identifiers
+// are optionally replaced by the shortest free names.
+// The result is type-checked before it is returned.
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"sort"
+)
+
+const nameChars = "abcdefghijklmnopqrstuvwxyz"
+
+// minify returns the source without comments and redundant whitespace,
+// unexported identifiers are shortened if rename is set.
+func minify(src []byte, rename bool) ([]byte, error) {
+	var err error
+	if rename {
+		if src, err = shortenNames(src); err != nil {
+			return nil, err
+		}
+	}
+
+	if src, err = compactTokens(src); err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "bundle.go", src, 0)
+	if err != nil {
+		return nil, fmt.Errorf("minified source: %w", err)
+	}
+	if _, _, err = typeCheck(fset, file, types.Config{}); err != nil {
+		return nil, fmt.Errorf("minified source: %w", err)
+	}
+
+	return src, nil
+}
+
+// shortenNames renames the unexported identifiers to the shortest names not used in the source,
+// the most used identifiers get the shortest names.
+func shortenNames(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "bundle.go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	_, info, err := typeCheck(fset, file, types.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	// every name in the source is taken, so new names never shadow anything
+	taken := make(map[string]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			taken[id.Name] = true
+		}
+		return true
+	})
+	for _, name := range types.Universe.Names() {
+		taken[name] = true
+	}
+
+	embedded := embeddedTypes(info)
+	uses := make(map[types.Object]int)
+	var objs []types.Object
+	for id, obj := range info.Defs {
+		if !isRenamable(id, obj, embedded) {
+			continue
+		}
+		objs = append(objs, obj)
+	}
+	for _, obj := range info.Uses {
+		uses[obj]++
+	}
+	sort.Slice(objs, func(i, j int) bool {
+		if uses[objs[i]] != uses[objs[j]] {
+			return uses[objs[i]] > uses[objs[j]]
+		}
+		return objs[i].Pos() < objs[j].Pos()
+	})
+
+	names := make(map[types.Object]string, len(objs))
+	next := 0
+	for _, obj := range objs {
+		var name string
+		for {
+			name = shortName(next)
+			next++
+			if !taken[name] && token.Lookup(name) == token.IDENT {
+				break
+			}
+		}
+		names[obj] = name
+	}
+
+	for id, obj := range info.Defs {
+		if name, ok := names[obj]; ok {
+			id.Name = name
+		}
+	}
+	for id, obj := range info.Uses {
+		if name, ok := names[obj]; ok {
+			id.Name = name
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// isRenamable tests if the defined object can be renamed without changing the program.
+// Exported names, fields and methods are kept as they may be used by reflection or interfaces.
+func isRenamable(id *ast.Ident, obj types.Object, embedded map[types.Object]bool) bool {
+	if obj == nil || id.Name == "_" || id.Name == "main" || id.Name == "init" || obj.Exported() {
+		return false
+	}
+	switch obj := obj.(type) {
+	case *types.PkgName:
+		return false
+	case *types.Var:
+		return !obj.IsField()
+	case *types.Func:
+		return obj.Type().(*types.Signature).Recv() == nil
+	case *types.TypeName:
+		return !embedded[obj]
+	}
+	return true
+}
+
+// embeddedTypes returns the type names embedded in structs,
+// renaming them would rename the fields.
+func embeddedTypes(info *types.Info) map[types.Object]bool {
+	embedded := make(map[types.Object]bool)
+	for _, obj := range info.Defs {
+		v, ok := obj.(*types.Var)
+		if !ok || !v.Embedded() {
+			continue
+		}
+		t := v.Type()
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		if named, ok := t.(*types.Named); ok {
+			embedded[named.Obj()] = true
+		}
+	}
+	return embedded
+}
+
+// shortName returns the i-th name in the sequence a, b, ..., z, aa, ab, ...
+func shortName(i int) string {
+	var b []byte
+	for {
+		b = append([]byte{nameChars[i%len(nameChars)]}, b...)
+		i = i/len(nameChars) - 1
+		if i < 0 {
+			return string(b)
+		}
+	}
+}
+
+// srcToken is a scanned token of the source.
+type srcToken struct {
+	tok token.Token
+	lit string
+}
+
+// compactTokens rewrites the source as a sequence of tokens separated only where required.
+func compactTokens(src []byte) ([]byte, error) {
+	var errs scanner.ErrorList
+	fset := token.NewFileSet()
+	file := fset.AddFile("bundle.go", -1, len(src))
+
+	var s scanner.Scanner
+	s.Init(file, src, func(pos token.Position, msg string) {
+		errs.Add(pos, msg)
+	}, 0)
+
+	var tokens []srcToken
+	for {
+		_, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if lit == "" {
+			lit = tok.String()
+		}
+		if tok == token.SEMICOLON {
+			// automatic semicolons are newlines
+			lit = ";"
+		}
+		tokens = append(tokens, srcToken{tok, lit})
+	}
+	if errs.Len() > 0 {
+		return nil, errs.Err()
+	}
+
+	var buf bytes.Buffer
+	var prev srcToken
+	for i, t := range tokens {
+		if t.tok == token.SEMICOLON {
+			// a semicolon may be omitted before a closing ")" or "}"
+			if i+1 == len(tokens) || tokens[i+1].tok == token.RPAREN || tokens[i+1].tok == token.RBRACE {
+				continue
+			}
+		}
+		if buf.Len() > 0 && needsSpace(prev, t) {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(t.lit)
+		prev = t
+	}
+
+	return buf.Bytes(), nil
+}
+
+// needsSpace tests if two tokens written together are scanned differently.
+func needsSpace(a, b srcToken) bool {
+	src := a.lit + b.lit
+
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	s.Init(fset.AddFile("", -1, len(src)), []byte(src), nil, 0)
+
+	for _, want := range []srcToken{a, b} {
+		_, tok, lit := s.Scan()
+		if lit == "" {
+			lit = tok.String()
+		}
+		if tok != want.tok || (lit != want.lit && tok != token.SEMICOLON) {
+			return true
+		}
+	}
+	_, tok, lit := s.Scan()
+	// the scanner inserts a semicolon at the end of the line
+	if tok == token.SEMICOLON && lit == "\n" {
+		_, tok, _ = s.Scan()
+	}
+	return tok != token.EOF
+}
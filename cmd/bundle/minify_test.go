@@ -0,0 +1,99 @@
+package main
+
+import (
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var minifyTests = `package main
+
+import (
+	"fmt"
+)
+
+// Point is exported and keeps its name.
+type Point struct {
+	X, Y int
+}
+
+type inner struct {
+	value int
+}
+
+type outer struct {
+	inner
+	label string
+}
+
+var counter = 0
+
+// sum adds the numbers.
+func sum(values ...int) int {
+	total := 0
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
+
+func describe(v any) string {
+	switch x := v.(type) {
+	case int:
+		return fmt.Sprint(x + 1)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+func main() {
+	p := Point{1, 2}
+	o := outer{inner{3}, "o"}
+	counter++
+	text := ` + "`raw\nstring`" + `
+	fmt.Println(sum(p.X, p.Y, -1, - -1, o.value, counter), describe(o.label), text, 1.5, .5)
+}
+`
+
+func TestMinify(t *testing.T) {
+	src, err := minify([]byte(minifyTests), false)
+	require.NoError(t, err)
+
+	out := string(src)
+	assert.NotContains(t, out, "//")
+	assert.NotContains(t, out, "\t")
+	assert.Contains(t, out, "package main;import(\"fmt\");type Point struct{X,Y int};")
+	assert.Contains(t, out, "func sum(values...int)int{total:=0;")
+	assert.Contains(t, out, "-1,- -1,")
+	assert.Contains(t, out, "`raw\nstring`")
+	assert.Equal(t, 1, strings.Count(out, "\n"))
+}
+
+func TestMinify_Rename(t *testing.T) {
+	src, err := minify([]byte(minifyTests), true)
+	require.NoError(t, err)
+
+	out := string(src)
+	assert.Contains(t, out, "type Point struct{X,Y int}")
+	assert.Contains(t, out, "type inner struct{value int}")
+	assert.NotContains(t, out, "func sum(")
+	assert.NotContains(t, out, "total")
+	assert.NotContains(t, out, "counter")
+	assert.Contains(t, out, "func main()")
+}
+
+func TestShortName(t *testing.T) {
+	assert.Equal(t, "a", shortName(0))
+	assert.Equal(t, "z", shortName(25))
+	assert.Equal(t, "aa", shortName(26))
+	assert.Equal(t, "az", shortName(51))
+	assert.Equal(t, "ba", shortName(52))
+}
+
+func TestNeedsSpace(t *testing.T) {
+	assert.True(t, needsSpace(srcToken{tok: token.IDENT, lit: "x"}, srcToken{tok: token.IDENT, lit: "y"}))
+	assert.False(t, needsSpace(srcToken{tok: token.IDENT, lit: "x"}, srcToken{tok: token.LPAREN, lit: "("}))
+}
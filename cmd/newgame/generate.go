@@ -0,0 +1,584 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+)
+
+// goTypes are the Go types of the field types.
+var goTypes = map[string]string{
+	"int":    "int",
+	"float":  "float64",
+	"string": "string",
+}
+
+// formatVerbs are the fmt verbs of the field types in the commands.
+var formatVerbs = map[string]string{
+	"int":    "%d",
+	"float":  "%.f",
+	"string": "%s",
+}
+
+// section is the input of the game or a turn.
+type section struct {
+	// Type is the struct type, Var is the variable name in the input function.
+	Type  string
+	Var   string
+	Lines []Line
+}
+
+// sections returns the game and the turn sections of the protocol.
+func (p *Protocol) sections() (game, turn section) {
+	return section{Type: "Game", Var: "game", Lines: p.Game},
+		section{Type: "Turn", Var: "turn", Lines: p.Turn}
+}
+
+// checkNames tests if the generated types do not collide with the framework declarations.
+func checkNames(p *Protocol, reserved map[string]bool) error {
+	var names []string
+	for _, lines := range [][]Line{p.Game, p.Turn} {
+		for _, l := range lines {
+			if l.List != "" {
+				names = append(names, itemType(l.List))
+			}
+		}
+	}
+	for _, v := range p.Commands {
+		names = append(names, commandType(v))
+	}
+
+	seen := map[string]bool{"Game": true, "Turn": true, "Command": true, "Commands": true}
+	for _, name := range names {
+		if reserved[name] || seen[name] {
+			return fmt.Errorf("type %s collides with another declaration", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// generate returns the sources of the game files by the file name.
+func generate(p *Protocol) (map[string][]byte, error) {
+	game, turn := p.sections()
+	files := map[string]string{
+		"input.go":        genInput(game, turn),
+		"reader.go":       genReader(game, turn),
+		"command.go":      genCommand(p.Commands),
+		"main.go":         genMain(p.Commands),
+		"input_test.go":   genInputTest(game, turn),
+		"reader_test.go":  genReaderTest(game, turn),
+		"command_test.go": genCommandTest(p.Commands),
+	}
+
+	sources := make(map[string][]byte, len(files))
+	for name, src := range files {
+		formatted, err := format.Source([]byte(src))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		sources[name] = formatted
+	}
+	return sources, nil
+}
+
+// genInput returns the input structs and parsers.
+func genInput(sections ...section) string {
+	var b bytes.Buffer
+	b.WriteString("package main\n\nimport (\n\t\"fmt\"\n)\n\n")
+
+	for _, s := range sections {
+		for _, l := range s.Lines {
+			if l.List == "" {
+				continue
+			}
+			fmt.Fprintf(&b, "type %s struct {\n", itemType(l.List))
+			writeFields(&b, l.Fields)
+			b.WriteString("}\n\n")
+		}
+
+		fmt.Fprintf(&b, "type %s struct {\n", s.Type)
+		for _, l := range s.Lines {
+			if l.List != "" {
+				fmt.Fprintf(&b, "\t%s []%s\n", exportName(l.List), itemType(l.List))
+				continue
+			}
+			writeFields(&b, l.Fields)
+		}
+		b.WriteString("}\n\n")
+	}
+
+	for _, s := range sections {
+		name := "InputGame"
+		if s.Type == "Turn" {
+			name = "InputStep"
+		}
+		fmt.Fprintf(&b, "func %s(data []string) %s {\n", name, s.Type)
+
+		var body bytes.Buffer
+		var scans bool
+		for i, l := range s.Lines {
+			last := i == len(s.Lines)-1
+			if l.List == "" {
+				scans = writeScan(&body, "data[0]", s.Var, l.Fields) || scans
+				if !last {
+					body.WriteString("\tdata = data[1:]\n")
+				}
+				body.WriteString("\n")
+				continue
+			}
+
+			list := s.Var + "." + exportName(l.List)
+			count := l.Count
+			if _, err := strconv.Atoi(count); err != nil {
+				count = s.Var + "." + exportName(count)
+			}
+			fmt.Fprintf(&body, "\t%s = make([]%s, %s)\n", list, itemType(l.List), count)
+			fmt.Fprintf(&body, "\tif debugBuild {\n\t\tinvariant(len(data) >= len(%s), \"expected %%d %s, got %%d lines\", len(%s), len(data))\n\t}\n",
+				list, l.List, list)
+			fmt.Fprintf(&body, "\tfor i := range %s {\n", list)
+			scans = writeScan(&body, "data[i]", list+"[i]", l.Fields) || scans
+			body.WriteString("\t}\n")
+			if !last {
+				fmt.Fprintf(&body, "\tdata = data[len(%s):]\n", list)
+			}
+			body.WriteString("\n")
+		}
+
+		if scans {
+			b.WriteString("\tvar err error\n")
+		}
+		fmt.Fprintf(&b, "\tvar %s %s\n\n", s.Var, s.Type)
+		b.Write(body.Bytes())
+		fmt.Fprintf(&b, "\treturn %s\n}\n\n", s.Var)
+	}
+
+	return b.String()
+}
+
+// writeFields writes the struct fields.
+func writeFields(b *bytes.Buffer, fields []Field) {
+	for _, f := range fields {
+		fmt.Fprintf(b, "\t%s %s\n", exportName(f.Name), goTypes[f.Type])
+	}
+}
+
+// writeScan writes the parsing of the line into the fields of the value
+// and returns false when the line is assigned without scanning.
+func writeScan(b *bytes.Buffer, line, value string, fields []Field) bool {
+	// a string line is taken as is, it may contain spaces
+	if len(fields) == 1 && fields[0].Type == "string" {
+		fmt.Fprintf(b, "\t%s.%s = %s\n", value, exportName(fields[0].Name), line)
+		return false
+	}
+
+	args := make([]string, len(fields))
+	for i, f := range fields {
+		args[i] = "&" + value + "." + exportName(f.Name)
+	}
+	fmt.Fprintf(b, "\t_, err = fmt.Sscan(%s, %s)\n", line, strings.Join(args, ", "))
+	b.WriteString("\tif err != nil {\n\t\tpanic(err)\n\t}\n")
+	return true
+}
+
+// genReader returns the readers of the input lines.
+func genReader(sections ...section) string {
+	var b bytes.Buffer
+	var fields bool
+	var body bytes.Buffer
+
+	for _, s := range sections {
+		name, doc, capacity := "ReadGame", "the game state", 32
+		if s.Type == "Turn" {
+			name, doc = "ReadStep", "the game turn state"
+			capacity = len(s.Lines)
+			for _, l := range s.Lines {
+				if l.List != "" {
+					capacity = 32
+				}
+			}
+		}
+
+		// the int fields used as counts of lists
+		counts := make(map[string]bool)
+		for _, l := range s.Lines {
+			counts[l.Count] = true
+		}
+
+		fmt.Fprintf(&body, "// %s reads %s from the standard input stream.\n", name, doc)
+		fmt.Fprintf(&body, "func %s(s *bufio.Scanner) []string {\n", name)
+		fmt.Fprintf(&body, "\tdata := make([]string, 0, %d)\n\n", capacity)
+		for i, l := range s.Lines {
+			if l.List != "" {
+				count := l.Count
+				if _, err := strconv.Atoi(count); err != nil {
+					count = localName(count)
+				}
+				fmt.Fprintf(&body, "\tfor i := 0; i < %s; i++ {\n\t\ts.Scan()\n\t\tdata = append(data, s.Text())\n\t}\n", count)
+				continue
+			}
+
+			body.WriteString("\ts.Scan()\n\tdata = append(data, s.Text())\n")
+			if i == 0 && s.Type == "Turn" && len(s.Lines) > 1 {
+				body.WriteString("\t// the input is over\n\tif data[0] == \"\" {\n\t\treturn data\n\t}\n")
+			}
+			for j, f := range l.Fields {
+				if !counts[f.Name] {
+					continue
+				}
+				if len(l.Fields) == 1 {
+					fmt.Fprintf(&body, "\t%s := StrToInt(s.Text())\n", localName(f.Name))
+				} else {
+					fields = true
+					fmt.Fprintf(&body, "\t%s := StrToInt(strings.Fields(s.Text())[%d])\n", localName(f.Name), j)
+				}
+			}
+		}
+		body.WriteString("\n\treturn data\n}\n\n")
+	}
+
+	b.WriteString("package main\n\nimport (\n\t\"bufio\"\n")
+	if fields {
+		b.WriteString("\t\"strings\"\n")
+	}
+	b.WriteString(")\n\n// Reading the game state from the standard input stream.\n\n")
+	b.Write(body.Bytes())
+
+	return b.String()
+}
+
+// commandType returns the type name of the command, "MOVE_TO" becomes "MoveToCommand".
+func commandType(v Verb) string {
+	return exportName(strings.ToLower(v.Name)) + "Command"
+}
+
+// fallbackVerb returns the command emitted when the bot fails, the first one without arguments.
+func fallbackVerb(verbs []Verb) Verb {
+	for _, v := range verbs {
+		if len(v.Fields) == 0 {
+			return v
+		}
+	}
+	return verbs[0]
+}
+
+// genCommand returns the command types.
+func genCommand(verbs []Verb) string {
+	var b bytes.Buffer
+	b.WriteString(`package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Command is an interface for game commands.
+
+var commandOutput io.Writer = os.Stdout
+
+type Command interface {
+	String() string
+}
+
+type Commands []Command
+
+`)
+	fmt.Fprintf(&b, "// fallbackCommands are emitted when the bot fails the turn.\nvar fallbackCommands = Commands{%s{}}\n\n",
+		commandType(fallbackVerb(verbs)))
+
+	for _, v := range verbs {
+		name := commandType(v)
+		if len(v.Fields) == 0 {
+			fmt.Fprintf(&b, "type %s struct{}\n\n", name)
+		} else {
+			fmt.Fprintf(&b, "type %s struct {\n", name)
+			writeFields(&b, v.Fields)
+			b.WriteString("}\n\n")
+		}
+
+		fmt.Fprintf(&b, "func (c %s) String() string {\n", name)
+		if len(v.Fields) == 0 {
+			fmt.Fprintf(&b, "\treturn %q\n}\n\n", v.Name)
+			continue
+		}
+		format := v.Name
+		args := make([]string, len(v.Fields))
+		for i, f := range v.Fields {
+			format += " " + formatVerbs[f.Type]
+			args[i] = "c." + exportName(f.Name)
+		}
+		fmt.Fprintf(&b, "\treturn fmt.Sprintf(%q, %s)\n}\n\n", format, strings.Join(args, ", "))
+	}
+
+	b.WriteString(`func ExecuteCommands(commands Commands) {
+	for _, command := range commands {
+		fmt.Fprintln(commandOutput, command)
+	}
+}
+`)
+	return b.String()
+}
+
+// genMain returns the bot skeleton wired to the runner.
+func genMain(verbs []Verb) string {
+	return fmt.Sprintf(`package main
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+var seed int64
+var rnd *Rand
+
+func init() {
+	runtime.GOMAXPROCS(1)
+	// the seed is fixed by the BOT_SEED environment variable to reproduce a match
+	seed = time.Now().UnixNano()
+	if s, err := strconv.ParseInt(os.Getenv("BOT_SEED"), 10, 64); err == nil {
+		seed = s
+	}
+	rnd = NewRand(seed)
+	debug = true
+}
+
+// bot is the game strategy.
+type bot struct {
+	game Game
+}
+
+func (b *bot) Init(game Game) {
+	b.game = game
+}
+
+func (b *bot) Turn(step Turn) Commands {
+	// the game logic for the step
+
+	return Commands{%s{}}
+}
+
+func main() {
+	runner := NewRunner(&bot{}, os.Stdin)
+	runner.Seed = seed
+	runner.Run()
+}
+`, commandType(verbs[0]))
+}
+
+// sample generates the test values of the fields.
+type sample struct {
+	n int
+}
+
+// value returns the next input value of the field and the Go literal of it.
+func (s *sample) value(f Field) (string, string) {
+	s.n++
+	switch f.Type {
+	case "float":
+		v := strconv.Itoa(s.n) + ".5"
+		return v, v
+	case "string":
+		v := "s" + strconv.Itoa(s.n)
+		return v, strconv.Quote(v)
+	}
+	v := strconv.Itoa(s.n)
+	return v, v
+}
+
+// sampleCount is the value of the count fields in the sample data.
+const sampleCount = 2
+
+// data returns the sample input lines of the section and the Go literal of the parsed value.
+func (s *sample) data(sec section) ([]string, string) {
+	var lines []string
+	var lit strings.Builder
+	counts := make(map[string]bool)
+	for _, l := range sec.Lines {
+		counts[l.Count] = true
+	}
+
+	fmt.Fprintf(&lit, "%s{\n", sec.Type)
+	for _, l := range sec.Lines {
+		if l.List == "" {
+			values := make([]string, len(l.Fields))
+			for i, f := range l.Fields {
+				var v string
+				values[i], v = s.value(f)
+				if counts[f.Name] {
+					values[i], v = strconv.Itoa(sampleCount), strconv.Itoa(sampleCount)
+				}
+				fmt.Fprintf(&lit, "%s: %s,\n", exportName(f.Name), v)
+			}
+			lines = append(lines, strings.Join(values, " "))
+			continue
+		}
+
+		n, err := strconv.Atoi(l.Count)
+		if err != nil {
+			n = sampleCount
+		}
+		fmt.Fprintf(&lit, "%s: []%s{\n", exportName(l.List), itemType(l.List))
+		for row := 0; row < n; row++ {
+			values := make([]string, len(l.Fields))
+			items := make([]string, len(l.Fields))
+			for i, f := range l.Fields {
+				var v string
+				values[i], v = s.value(f)
+				items[i] = exportName(f.Name) + ": " + v
+			}
+			lines = append(lines, strings.Join(values, " "))
+			fmt.Fprintf(&lit, "{%s},\n", strings.Join(items, ", "))
+		}
+		lit.WriteString("},\n")
+	}
+	lit.WriteString("}")
+
+	return lines, lit.String()
+}
+
+// genInputTest returns the tests of the input parsers.
+func genInputTest(game, turn section) string {
+	var s sample
+	_, gameLit := s.data(game)
+	_, turnLit := s.data(turn)
+
+	return fmt.Sprintf(`package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInputGame(t *testing.T) {
+	game := InputGame(readGameTests)
+	want := %s
+	assert.Equal(t, want, game)
+}
+
+func TestInputStep(t *testing.T) {
+	turn := InputStep(readStepTests)
+	want := %s
+	assert.Equal(t, want, turn)
+}
+`, gameLit, turnLit)
+}
+
+// genReaderTest returns the sample data and the tests of the readers.
+func genReaderTest(game, turn section) string {
+	var s sample
+	gameData, _ := s.data(game)
+	turnData, _ := s.data(turn)
+
+	return fmt.Sprintf(`package main
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var readGameTests = %s
+
+func TestReadGame(t *testing.T) {
+	s := strings.Join(readGameTests, "\n")
+	r := strings.NewReader(s)
+	b := bufio.NewScanner(r)
+
+	data := ReadGame(b)
+	assert.Equal(t, readGameTests, data)
+}
+
+var readStepTests = %s
+
+func TestReadStep(t *testing.T) {
+	s := strings.Join(readStepTests, "\n")
+	r := strings.NewReader(s)
+	b := bufio.NewScanner(r)
+
+	data := ReadStep(b)
+	assert.Equal(t, readStepTests, data)
+}
+`, stringsLiteral(gameData), stringsLiteral(turnData))
+}
+
+// stringsLiteral returns the Go literal of the lines.
+func stringsLiteral(lines []string) string {
+	var b strings.Builder
+	b.WriteString("[]string{\n")
+	for _, l := range lines {
+		fmt.Fprintf(&b, "%q,\n", l)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// genCommandTest returns the tests of the commands.
+func genCommandTest(verbs []Verb) string {
+	var b bytes.Buffer
+	b.WriteString(`package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockWriter struct {
+	data []byte
+}
+
+func (w *mockWriter) Write(p []byte) (n int, err error) {
+	w.data = p
+	return len(p), nil
+}
+`)
+
+	var example, output string
+	for _, v := range verbs {
+		args := make([]string, len(v.Fields))
+		want := v.Name
+		for i, f := range v.Fields {
+			value := strconv.Itoa(i + 1)
+			args[i] = value
+			if f.Type == "string" {
+				value = "s" + value
+				args[i] = strconv.Quote(value)
+			}
+			want += " " + value
+		}
+		cmd := fmt.Sprintf("%s{%s}", commandType(v), strings.Join(args, ", "))
+		if example == "" {
+			example, output = cmd, want
+		}
+		fmt.Fprintf(&b, `
+func Test%s_String(t *testing.T) {
+	cmd := %s
+	assert.Equal(t, %q, cmd.String())
+}
+`, commandType(v), cmd, want)
+	}
+
+	fmt.Fprintf(&b, `
+func TestExecuteCommand(t *testing.T) {
+	w := &mockWriter{}
+	prev := commandOutput
+	commandOutput = w
+	t.Cleanup(func() {
+		commandOutput = prev
+	})
+	ExecuteCommands(Commands{%s})
+
+	want := %q
+	assert.Equal(t, want, string(w.data))
+}
+`, example, output+"\n")
+	return b.String()
+}
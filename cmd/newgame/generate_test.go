@@ -0,0 +1,88 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseTestProtocol(t *testing.T, path string) *Protocol {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	p, err := ParseProtocol(f)
+	require.NoError(t, err)
+	return p
+}
+
+func TestGenerate_Mock(t *testing.T) {
+	sources, err := generate(parseTestProtocol(t, "testdata/mock.txt"))
+	require.NoError(t, err)
+
+	input := string(sources["input.go"])
+	assert.Contains(t, input, "type Unit struct {\n\tX float64\n\tY float64\n\tZ float64\n}")
+	assert.Contains(t, input, "type Game struct {\n\tCount int\n\tUnits []Unit\n}")
+	assert.Contains(t, input, "_, err = fmt.Sscan(data[0], &turn.Power, &turn.L, &turn.R)")
+
+	reader := string(sources["reader.go"])
+	assert.Contains(t, reader, "count := StrToInt(s.Text())\n\tfor i := 0; i < count; i++ {")
+	assert.NotContains(t, reader, "the input is over")
+
+	command := string(sources["command.go"])
+	assert.Contains(t, command, `return fmt.Sprintf("MOVE %.f %.f", c.X, c.Y)`)
+	assert.Contains(t, command, "var fallbackCommands = Commands{MoveCommand{}}")
+
+	readerTest := string(sources["reader_test.go"])
+	assert.Contains(t, readerTest, "var readGameTests = []string{\n\t\"2\",\n\t\"2.5 3.5 4.5\",\n\t\"5.5 6.5 7.5\",\n}")
+	assert.Contains(t, readerTest, "var readStepTests = []string{\n\t\"8.5 s9 s10\",\n}")
+}
+
+func TestCheckNames(t *testing.T) {
+	p := parseTestProtocol(t, "testdata/contest.txt")
+	assert.NoError(t, checkNames(p, map[string]bool{"Point": true}))
+	assert.EqualError(t, checkNames(p, map[string]bool{"Unit": true}), "type Unit collides with another declaration")
+}
+
+// TestGenerate_Build generates the game with the framework of the repository and runs its tests.
+func TestGenerate_Build(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs go test")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("no go command")
+	}
+
+	p := parseTestProtocol(t, "testdata/contest.txt")
+	framework, reserved, err := frameworkFiles("../..")
+	require.NoError(t, err)
+	require.NoError(t, checkNames(p, reserved))
+	assert.NotContains(t, framework, "main.go")
+	assert.NotContains(t, framework, "runner_test.go")
+
+	sources, err := generate(p)
+	require.NoError(t, err)
+
+	// the package must be inside the module to build with its dependencies
+	dir, err := os.MkdirTemp("testdata", "game")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	for name, data := range framework {
+		sources[name] = data
+	}
+	for name, data := range sources {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), data, 0o644))
+	}
+
+	cmd := exec.Command(gobin, "test", "./"+filepath.Base(dir))
+	cmd.Dir = "testdata"
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	assert.True(t, strings.HasPrefix(string(out), "ok"), string(out))
+}
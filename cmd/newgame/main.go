@@ -0,0 +1,123 @@
+package main
+
+// Newgame creates the bot of a new game from the protocol description:
+// the input structs and parsers, the readers, the command types, their tests
+// with sample data and a bot skeleton wired to the runner. The framework files
+// are copied alongside.
+//
+// Usage:
+//
+//	go run ./cmd/newgame [-src .] -o games/contest protocol.txt
+//
+// See ParseProtocol for the description format.
+
+import (
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// gameFiles are the framework files replaced by the generated ones.
+var gameFiles = map[string]bool{
+	"input.go":        true,
+	"reader.go":       true,
+	"command.go":      true,
+	"main.go":         true,
+	"params_tuned.go": true,
+}
+
+func main() {
+	src := flag.String("src", ".", "directory of the framework")
+	output := flag.String("o", "", "directory of the new game")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("newgame: ")
+
+	if flag.NArg() != 1 || *output == "" {
+		log.Fatal("want -o dir and the protocol file")
+	}
+
+	f, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	protocol, err := ParseProtocol(f)
+	f.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	framework, reserved, err := frameworkFiles(*src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = checkNames(protocol, reserved); err != nil {
+		log.Fatal(err)
+	}
+	sources, err := generate(protocol)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if entries, _ := os.ReadDir(*output); len(entries) > 0 {
+		log.Fatalf("%s is not empty", *output)
+	}
+	if err = os.MkdirAll(*output, 0o755); err != nil {
+		log.Fatal(err)
+	}
+	for name, data := range framework {
+		sources[name] = data
+	}
+	names := make([]string, 0, len(sources))
+	for name, data := range sources {
+		if err = os.WriteFile(filepath.Join(*output, name), data, 0o644); err != nil {
+			log.Fatal(err)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	log.Printf("%d files written to %s: %s", len(names), *output, strings.Join(names, " "))
+}
+
+// frameworkFiles returns the contents of the non-test framework files
+// except the game ones and the names of their top-level declarations.
+func frameworkFiles(dir string) (map[string][]byte, map[string]bool, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := make(map[string][]byte)
+	names := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if gameFiles[name] || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		file, err := parser.ParseFile(fset, path, data, 0)
+		if err != nil {
+			return nil, nil, err
+		}
+		for name := range file.Scope.Objects {
+			names[name] = true
+		}
+		files[name] = data
+	}
+	if len(files) == 0 {
+		return nil, nil, fmt.Errorf("no framework files in %s", dir)
+	}
+
+	return files, names, nil
+}
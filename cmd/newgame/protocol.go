@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"go/token"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Protocol is the description of the game input and the commands.
+type Protocol struct {
+	Game     []Line
+	Turn     []Line
+	Commands []Verb
+}
+
+// Line is an input line of fields, or a list of lines when the Count is set.
+type Line struct {
+	// List is the name of the list, empty for a single line.
+	List string
+	// Count is the name of a preceding int field or a number of lines.
+	Count  string
+	Fields []Field
+}
+
+// Verb is a command with arguments.
+type Verb struct {
+	Name   string
+	Fields []Field
+}
+
+// Field is a named value of the type int, float or string.
+type Field struct {
+	Name string
+	Type string
+}
+
+var (
+	listRe  = regexp.MustCompile(`^([A-Za-z]\w*)\[(\w+)\]$`)
+	nameRe  = regexp.MustCompile(`^[A-Za-z]\w*$`)
+	fieldRe = regexp.MustCompile(`^([A-Za-z]\w*):(int|float|string)$`)
+)
+
+// ParseProtocol reads the protocol description.
+//
+//	game:
+//	width:int height:int
+//	count:int
+//	units[count] id:int x:int y:int owner:int
+//	turn:
+//	score:int
+//	commands:
+//	MOVE id:int x:int y:int
+//	WAIT
+//
+// The game and turn sections list the input lines, a line starting with list[count]
+// is repeated by the value of a preceding int field of the section or a fixed number of times.
+// The commands section lists the verbs with arguments. Empty lines and # comments are skipped.
+func ParseProtocol(r io.Reader) (*Protocol, error) {
+	p := &Protocol{}
+	var section string
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		text := s.Text()
+		if i := strings.IndexByte(text, '#'); i >= 0 {
+			text = text[:i]
+		}
+		tokens := strings.Fields(text)
+		if len(tokens) == 0 {
+			continue
+		}
+
+		if len(tokens) == 1 && strings.HasSuffix(tokens[0], ":") && !strings.Contains(tokens[0][:len(tokens[0])-1], ":") {
+			section = strings.TrimSuffix(tokens[0], ":")
+			if section != "game" && section != "turn" && section != "commands" {
+				return nil, fmt.Errorf("line %d: unknown section %q", n, section)
+			}
+			continue
+		}
+
+		var err error
+		switch section {
+		case "game":
+			p.Game, err = parseLine(p.Game, tokens)
+		case "turn":
+			p.Turn, err = parseLine(p.Turn, tokens)
+		case "commands":
+			p.Commands, err = parseVerb(p.Commands, tokens)
+		default:
+			err = fmt.Errorf("no section")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(p.Game) == 0 || len(p.Turn) == 0 || len(p.Commands) == 0 {
+		return nil, fmt.Errorf("want game, turn and commands sections")
+	}
+	return p, nil
+}
+
+// parseLine appends the input line to the section.
+func parseLine(lines []Line, tokens []string) ([]Line, error) {
+	// the names of the section struct fields and the list item types
+	names := make(map[string]bool)
+	for _, l := range lines {
+		if l.List != "" {
+			names[exportName(l.List)] = true
+			names[itemType(l.List)] = true
+			continue
+		}
+		for _, f := range l.Fields {
+			names[exportName(f.Name)] = true
+		}
+	}
+
+	var line Line
+	if m := listRe.FindStringSubmatch(tokens[0]); m != nil {
+		line.List, line.Count = m[1], m[2]
+		tokens = tokens[1:]
+		if err := checkCount(lines, line.Count); err != nil {
+			return nil, err
+		}
+		if names[exportName(line.List)] || names[itemType(line.List)] {
+			return nil, fmt.Errorf("duplicate list %q", line.List)
+		}
+	}
+
+	fields, err := parseFields(tokens)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields")
+	}
+	line.Fields = fields
+
+	if line.List == "" {
+		for _, f := range fields {
+			if names[exportName(f.Name)] {
+				return nil, fmt.Errorf("duplicate field %q", f.Name)
+			}
+		}
+	}
+
+	return append(lines, line), nil
+}
+
+// checkCount tests if the count is a positive number or a preceding int field of the section.
+func checkCount(lines []Line, count string) error {
+	if n, err := strconv.Atoi(count); err == nil {
+		if n <= 0 {
+			return fmt.Errorf("count %d is not positive", n)
+		}
+		return nil
+	}
+	for _, l := range lines {
+		if l.List != "" {
+			continue
+		}
+		for _, f := range l.Fields {
+			if f.Name == count {
+				if f.Type != "int" {
+					return fmt.Errorf("count %q is not int", count)
+				}
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("unknown count %q", count)
+}
+
+// parseVerb appends the command to the list.
+func parseVerb(verbs []Verb, tokens []string) ([]Verb, error) {
+	if !nameRe.MatchString(tokens[0]) {
+		return nil, fmt.Errorf("invalid command %q", tokens[0])
+	}
+	fields, err := parseFields(tokens[1:])
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range verbs {
+		if commandType(v) == commandType(Verb{Name: tokens[0]}) {
+			return nil, fmt.Errorf("duplicate command %q", tokens[0])
+		}
+	}
+	return append(verbs, Verb{Name: tokens[0], Fields: fields}), nil
+}
+
+// parseFields returns the fields of the form "name:type".
+func parseFields(tokens []string) ([]Field, error) {
+	fields := make([]Field, 0, len(tokens))
+	seen := make(map[string]bool)
+	for _, t := range tokens {
+		m := fieldRe.FindStringSubmatch(t)
+		if m == nil {
+			return nil, fmt.Errorf("invalid field %q, want name:int, name:float or name:string", t)
+		}
+		if seen[exportName(m[1])] {
+			return nil, fmt.Errorf("duplicate field %q", m[1])
+		}
+		seen[exportName(m[1])] = true
+		fields = append(fields, Field{Name: m[1], Type: m[2]})
+	}
+	return fields, nil
+}
+
+// exportName returns the exported Go name, "unit_count" becomes "UnitCount".
+func exportName(name string) string {
+	var b strings.Builder
+	for _, w := range strings.Split(name, "_") {
+		if w == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// localName returns the name of a local variable, "UnitCount" becomes "unitCount".
+func localName(name string) string {
+	name = exportName(name)
+	name = string(unicode.ToLower(rune(name[0]))) + name[1:]
+	switch {
+	case token.IsKeyword(name), name == "data", name == "s", name == "i", name == "err":
+		return "n" + exportName(name)
+	}
+	return name
+}
+
+// itemType returns the type name of the list item, "units" becomes "Unit".
+func itemType(list string) string {
+	name := exportName(list)
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "ss"):
+		return name
+	case strings.HasSuffix(name, "s"):
+		return name[:len(name)-1]
+	}
+	return name
+}
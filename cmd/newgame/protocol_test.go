@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProtocol(t *testing.T) {
+	f, err := os.Open("testdata/mock.txt")
+	require.NoError(t, err)
+	defer f.Close()
+
+	p, err := ParseProtocol(f)
+	require.NoError(t, err)
+	want := &Protocol{
+		Game: []Line{
+			{Fields: []Field{{"count", "int"}}},
+			{List: "units", Count: "count", Fields: []Field{{"x", "float"}, {"y", "float"}, {"z", "float"}}},
+		},
+		Turn: []Line{
+			{Fields: []Field{{"power", "float"}, {"l", "string"}, {"r", "string"}}},
+		},
+		Commands: []Verb{
+			{Name: "MOVE", Fields: []Field{{"x", "float"}, {"y", "float"}}},
+		},
+	}
+	assert.Equal(t, want, p)
+}
+
+var parseProtocolErrorTests = []struct {
+	name, src, err string
+}{
+	{"no section", "count:int", "line 1: no section"},
+	{"unknown section", "init:", `unknown section "init"`},
+	{"missing section", "game:\ncount:int\nturn:\ncount:int", "want game, turn and commands sections"},
+	{"field type", "game:\ncount:uint", `invalid field "count:uint"`},
+	{"duplicate field", "game:\ncount:int\ncount:int", `line 3: duplicate field "count"`},
+	{"unknown count", "game:\nunits[count] x:int", `unknown count "count"`},
+	{"float count", "game:\ncount:float\nunits[count] x:int", `count "count" is not int`},
+	{"zero count", "game:\nunits[0] x:int", "count 0 is not positive"},
+	{"duplicate list", "game:\ncount:int\nunits[count] x:int\nunit[count] y:int", `duplicate list "unit"`},
+	{"no fields", "game:\nunits[2]", "no fields"},
+	{"duplicate command", "commands:\nWAIT\nwait", `duplicate command "wait"`},
+	{"invalid command", "commands:\n1WAIT", `invalid command "1WAIT"`},
+}
+
+func TestParseProtocol_Error(t *testing.T) {
+	for _, tt := range parseProtocolErrorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseProtocol(strings.NewReader(tt.src))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, "UnitCount", exportName("unit_count"))
+	assert.Equal(t, "MyScore", exportName("myScore"))
+	assert.Equal(t, "unitCount", localName("unit_count"))
+	assert.Equal(t, "nType", localName("type"))
+	assert.Equal(t, "nData", localName("data"))
+	assert.Equal(t, "Unit", itemType("units"))
+	assert.Equal(t, "Entity", itemType("entities"))
+	assert.Equal(t, "Class", itemType("class"))
+	assert.Equal(t, "Grid", itemType("grid"))
+	assert.Equal(t, "MoveToCommand", commandType(Verb{Name: "MOVE_TO"}))
+}
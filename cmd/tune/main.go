@@ -0,0 +1,89 @@
+package main
+
+// Tune optimizes the named parameters of a bot by local matches against itself
+// and writes the tuned values as Go constants.
+//
+// Usage:
+//
+//	go run ./cmd/tune -param aim=0.5:0:2 [-param decay=2:1:10] [-iterations 100] [-games 16] [-o params_tuned.go] "./bot"
+//
+// The bot reads the values from the BOT_PARAMS environment variable, see params.go.
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mrsombre/codingame-framework/arena"
+)
+
+// paramFlags is the repeated -param flag.
+type paramFlags []arena.Param
+
+func (ps *paramFlags) String() string {
+	return fmt.Sprint(*ps)
+}
+
+func (ps *paramFlags) Set(s string) error {
+	p, err := arena.ParseParam(s)
+	if err != nil {
+		return err
+	}
+	*ps = append(*ps, p)
+	return nil
+}
+
+func main() {
+	conf := arena.DefaultTuneConfig()
+	var params paramFlags
+	game := flag.String("game", "mock", "game of the referee")
+	flag.Var(&params, "param", "tuned parameter name=value:min:max, repeated")
+	flag.Int64Var(&conf.Seed, "seed", time.Now().UnixNano(), "seed of the matches")
+	flag.DurationVar(&conf.FirstTurnTimeout, "first-timeout", conf.FirstTurnTimeout, "first turn timeout")
+	flag.DurationVar(&conf.TurnTimeout, "timeout", conf.TurnTimeout, "turn timeout")
+	flag.IntVar(&conf.Iterations, "iterations", conf.Iterations, "number of iterations")
+	flag.IntVar(&conf.Games, "games", conf.Games, "number of matches per iteration")
+	flag.IntVar(&conf.Parallel, "parallel", 0, "number of matches played at once, the number of CPUs by default")
+	flag.Float64Var(&conf.A, "a", conf.A, "step size relative to the range")
+	flag.Float64Var(&conf.C, "c", conf.C, "perturbation size relative to the range")
+	output := flag.String("o", "", "file to write the constants to, stdout by default")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("tune: ")
+
+	newReferee := func() arena.Referee { return arena.NewReferee(*game) }
+	if newReferee() == nil {
+		log.Fatalf("unknown game %q", *game)
+	}
+	if flag.NArg() != 1 {
+		log.Fatal("want one bot")
+	}
+	if len(params) == 0 {
+		log.Fatal("no params")
+	}
+
+	tuned, err := arena.Tune(newReferee, arena.ParseBot(flag.Arg(0)), params, conf,
+		func(k int, ps []arena.Param, stats arena.Stats) {
+			values := make([]string, len(ps))
+			for i, p := range ps {
+				values[i] = fmt.Sprintf("%s=%.4g", p.Name, p.Value)
+			}
+			log.Printf("%d: %s, score %.1f%%", k, strings.Join(values, " "), stats.Score*100)
+		})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src := "// Code generated by tune. DO NOT EDIT.\n\npackage main\n\n" + arena.FormatParams(tuned)
+	if *output == "" {
+		fmt.Print(src)
+		return
+	}
+	if err = os.WriteFile(*output, []byte(src), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -16,6 +16,9 @@ type Command interface {
 
 type Commands []Command
 
+// fallbackCommands are emitted when the bot fails the turn.
+var fallbackCommands = Commands{MockCommand{}}
+
 type MockCommand struct {
 	Param1 float64
 	Param2 float64
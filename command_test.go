@@ -21,10 +21,14 @@ func TestMockCommand_String(t *testing.T) {
 }
 
 func TestExecuteCommand(t *testing.T) {
-	commandOutput = &mockWriter{}
+	w := &mockWriter{}
+	prev := commandOutput
+	commandOutput = w
+	t.Cleanup(func() {
+		commandOutput = prev
+	})
 	ExecuteCommands(Commands{MockCommand{1, 2}})
 
 	want := "1 2\n"
-	got := commandOutput.(*mockWriter).data
-	assert.Equal(t, want, string(got))
+	assert.Equal(t, want, string(w.data))
 }
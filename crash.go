@@ -0,0 +1,72 @@
+package main
+
+// Recovering from panics inside the turn loop.
+// On panic the state required to reproduce the turn locally is dumped
+// to the Stderr stream and a fallback command is emitted,
+// so the match continues instead of the bot dying.
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+)
+
+var crashOutput io.Writer = os.Stderr
+
+// CrashDump holds the last known state of the match.
+type CrashDump struct {
+	Turn int
+	Seed int64
+	Game []string
+	Step []string
+	// Watches are exported with the dump when set.
+	Watches *Watches
+}
+
+// Track stores the input of the current turn.
+func (d *CrashDump) Track(turn int, step []string) {
+	d.Turn = turn
+	d.Step = step
+}
+
+func (d CrashDump) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "turn: %d\n", d.Turn)
+	fmt.Fprintf(&b, "seed: %d\n", d.Seed)
+	if d.Game != nil {
+		fmt.Fprintf(&b, "game: %s\n", DataExport(d.Game))
+	}
+	if d.Step != nil {
+		fmt.Fprintf(&b, "step: %s\n", DataExport(d.Step))
+	}
+	if d.Watches != nil {
+		fmt.Fprintf(&b, "watch: %s\n", DataExport(d.Watches.Export()))
+	}
+	return b.String()
+}
+
+// SafeTurn runs the turn function and recovers from a panic,
+// printing the stack with the dump and returning the fallback commands.
+func SafeTurn(dump *CrashDump, fallback Commands, turn func() Commands) Commands {
+	return safeTurn(crashOutput, dump, fallback, turn)
+}
+
+// safeTurn is the SafeTurn printing to the writer.
+func safeTurn(w io.Writer, dump *CrashDump, fallback Commands, turn func() Commands) (commands Commands) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		stack := make([]byte, 64<<10)
+		stack = stack[:runtime.Stack(stack, false)]
+		fmt.Fprintf(w, "panic: %v\n%s%s\n", r, dump, stack)
+
+		commands = fallback
+	}()
+
+	return turn()
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrashDump_String(t *testing.T) {
+	dump := CrashDump{
+		Turn: 3,
+		Seed: 42,
+		Game: dataExportTests,
+	}
+
+	want := "turn: 3\nseed: 42\ngame: " + DataExport(dataExportTests) + "\n"
+	assert.Equal(t, want, dump.String())
+}
+
+func TestSafeTurn(t *testing.T) {
+	out := &bytes.Buffer{}
+	setOutput(t, &crashOutput, out)
+
+	dump := &CrashDump{Seed: 42}
+	dump.Track(7, readStepTests)
+	fallback := Commands{MockCommand{1, 2}}
+
+	got := SafeTurn(dump, fallback, func() Commands {
+		return Commands{MockCommand{3, 4}}
+	})
+	assert.Equal(t, Commands{MockCommand{3, 4}}, got)
+	assert.Empty(t, out.String())
+
+	got = SafeTurn(dump, fallback, func() Commands {
+		StrToInt("x")
+		return nil
+	})
+	assert.Equal(t, fallback, got)
+	assert.Contains(t, out.String(), "panic: strconv.Atoi")
+	assert.Contains(t, out.String(), "turn: 7\nseed: 42\n")
+	assert.Contains(t, out.String(), "step: "+DataExport(readStepTests))
+	assert.Contains(t, out.String(), "goroutine")
+}
@@ -2,25 +2,33 @@ package main
 
 // A set of helper methods for outputting debug information
 // to the Stderr stream in text or JSON format.
+// Built with the "release" tag every helper compiles to nothing, but the arguments
+// are still evaluated, so the calls with costly arguments are wrapped in `if isDebug() {}`.
 
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 )
 
 var debug = true
-var debugOutput = os.Stderr
+var debugOutput io.Writer = os.Stderr
+
+// isDebug tests if the debug output is enabled.
+func isDebug() bool {
+	return debugBuild && debug
+}
 
 func asText(a ...any) {
-	if !debug {
+	if !isDebug() {
 		return
 	}
 	fmt.Fprintln(debugOutput, a...)
 }
 
 func asJson(a any) {
-	if !debug {
+	if !isDebug() {
 		return
 	}
 	b, _ := json.Marshal(a)
@@ -28,12 +36,9 @@ func asJson(a any) {
 }
 
 func asJsonPretty(a any) {
-	if !debug {
+	if !isDebug() {
 		return
 	}
 	b, _ := json.MarshalIndent(a, ``, `  `)
 	asText(string(b))
 }
-
-func u(a ...any) {
-}
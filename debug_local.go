@@ -0,0 +1,6 @@
+//go:build !release
+
+package main
+
+// debugBuild enables debug output and invariant checks in local builds.
+const debugBuild = true
@@ -0,0 +1,6 @@
+//go:build release
+
+package main
+
+// debugBuild strips debug output and invariant checks from the submitted bundle.
+const debugBuild = false
@@ -0,0 +1,95 @@
+package main
+
+// Controlling the garbage collector over the turns.
+// A search allocating millions of nodes triggers the collector in the middle
+// of the turn, so it is disabled while the Bot decides and runs right after
+// the commands are written, while the opponent plays.
+
+import (
+	"fmt"
+	"runtime"
+	// the debug name is taken by the debug switch
+	rtdebug "runtime/debug"
+	"time"
+)
+
+// GCPolicy controls the garbage collector during the turns.
+type GCPolicy struct {
+	// Percent is the GOGC while the Bot decides, -1 disables the collector, 0 keeps the current value.
+	Percent int
+	// Collect runs the collection after the commands are written.
+	Collect bool
+}
+
+// DefaultGCPolicy disables the collector during the decision and collects after it.
+func DefaultGCPolicy() GCPolicy {
+	return GCPolicy{Percent: -1, Collect: true}
+}
+
+// Begin applies the policy for the decision and returns the function restoring the collector.
+func (p GCPolicy) Begin() func() {
+	if p.Percent == 0 {
+		return func() {}
+	}
+	old := rtdebug.SetGCPercent(p.Percent)
+	return func() {
+		rtdebug.SetGCPercent(old)
+	}
+}
+
+// End runs the collection when it is enabled and returns the time it took.
+func (p GCPolicy) End() time.Duration {
+	if !p.Collect {
+		return 0
+	}
+	start := time.Now()
+	runtime.GC()
+	return time.Since(start)
+}
+
+// GCStats are the heap growth and the collector pauses of a turn.
+type GCStats struct {
+	// HeapGrowth is the change of the live and not yet collected heap in bytes.
+	HeapGrowth int64
+	// Allocated is the total size of the allocations in bytes.
+	Allocated   uint64
+	Collections int
+	PauseTotal  time.Duration
+	MaxPause    time.Duration
+}
+
+func (s GCStats) String() string {
+	return fmt.Sprintf("heap %+.1fKB alloc %.1fKB collections %d pause %s max %s",
+		float64(s.HeapGrowth)/1024, float64(s.Allocated)/1024, s.Collections, s.PauseTotal, s.MaxPause)
+}
+
+// gcMeter measures the GCStats since the start.
+type gcMeter struct {
+	start runtime.MemStats
+}
+
+// Start reads the initial memory statistics, it stops the world for a moment.
+func (m *gcMeter) Start() {
+	runtime.ReadMemStats(&m.start)
+}
+
+// Stop returns the statistics since the start.
+func (m *gcMeter) Stop() GCStats {
+	var end runtime.MemStats
+	runtime.ReadMemStats(&end)
+
+	stats := GCStats{
+		HeapGrowth:  int64(end.HeapAlloc) - int64(m.start.HeapAlloc),
+		Allocated:   end.TotalAlloc - m.start.TotalAlloc,
+		Collections: int(end.NumGC - m.start.NumGC),
+		PauseTotal:  time.Duration(end.PauseTotalNs - m.start.PauseTotalNs),
+	}
+	// the recent pauses are kept in a circular buffer
+	for i := 0; i < stats.Collections && i < len(end.PauseNs); i++ {
+		pause := time.Duration(end.PauseNs[(int(end.NumGC)-1-i+len(end.PauseNs))%len(end.PauseNs)])
+		if pause > stats.MaxPause {
+			stats.MaxPause = pause
+		}
+	}
+	return stats
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"runtime"
+	rtdebug "runtime/debug"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCPolicy_Begin(t *testing.T) {
+	percent := rtdebug.SetGCPercent(100)
+	defer rtdebug.SetGCPercent(percent)
+
+	restore := DefaultGCPolicy().Begin()
+	assert.Equal(t, -1, rtdebug.SetGCPercent(-1))
+	restore()
+	assert.Equal(t, 100, rtdebug.SetGCPercent(100))
+
+	restore = GCPolicy{}.Begin()
+	assert.Equal(t, 100, rtdebug.SetGCPercent(100))
+	restore()
+}
+
+func TestGCPolicy_End(t *testing.T) {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	assert.Greater(t, DefaultGCPolicy().End(), time.Duration(0))
+	runtime.ReadMemStats(&after)
+	assert.Greater(t, after.NumGC, before.NumGC)
+
+	assert.Equal(t, time.Duration(0), GCPolicy{}.End())
+}
+
+var gcGarbage [][]byte
+
+func TestGCMeter(t *testing.T) {
+	var meter gcMeter
+	meter.Start()
+	for i := 0; i < 100; i++ {
+		gcGarbage = append(gcGarbage, make([]byte, 1024))
+	}
+	runtime.GC()
+	stats := meter.Stop()
+	gcGarbage = nil
+
+	assert.GreaterOrEqual(t, stats.Allocated, uint64(100*1024))
+	assert.LessOrEqual(t, stats.HeapGrowth, int64(stats.Allocated))
+	assert.GreaterOrEqual(t, stats.Collections, 1)
+	assert.Greater(t, stats.PauseTotal, time.Duration(0))
+	assert.Greater(t, stats.MaxPause, time.Duration(0))
+	assert.LessOrEqual(t, stats.MaxPause, stats.PauseTotal)
+}
+
+func TestGCStats_String(t *testing.T) {
+	stats := GCStats{HeapGrowth: -2048, Allocated: 10240, Collections: 2, PauseTotal: 300 * time.Microsecond, MaxPause: 200 * time.Microsecond}
+	assert.Equal(t, "heap -2.0KB alloc 10.0KB collections 2 pause 300µs max 200µs", stats.String())
+}
+
+func TestRunner_GC(t *testing.T) {
+	percent := rtdebug.SetGCPercent(100)
+	defer rtdebug.SetGCPercent(percent)
+	out := &strings.Builder{}
+	runner := newRunnerTests(t, &mockBot{}, strings.NewReader(strings.Join(runnerTests, "\n")))
+	setOutput(t, &debugOutput, out)
+	runner.Run()
+
+	assert.Equal(t, 100, rtdebug.SetGCPercent(100))
+	if debugBuild {
+		assert.Contains(t, out.String(), "gc heap ")
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// IPoint represents a point with the integer coordinates,
+// the grid games use it instead of Point to avoid the float math.
+type IPoint struct {
+	X, Y int
+}
+
+// IsSame tests if two IPoints are the same.
+func (p IPoint) IsSame(t IPoint) bool {
+	return p == t
+}
+
+// Index returns the 0-index of the IPoint in a field of specified width.
+func (p IPoint) Index(width int) int {
+	if p.X < 0 || p.X >= width || p.Y < 0 {
+		panic(fmt.Sprintf("point %s is out of bound %d", p, width))
+	}
+	return p.Y*width + p.X
+}
+
+// IsInXRange tests if the IPoint is in the range by X axis.
+func (p IPoint) IsInXRange(from, to int) bool {
+	return p.X >= from && p.X <= to
+}
+
+// IsInXBound tests if the IPoint is in the field defined by width.
+func (p IPoint) IsInXBound(width int) bool {
+	return p.X >= 0 && p.X < width
+}
+
+// IsInYRange tests if the IPoint is in the range by Y axis.
+func (p IPoint) IsInYRange(from, to int) bool {
+	return p.Y >= from && p.Y <= to
+}
+
+// IsInYBound tests if the IPoint is in the field defined by height.
+func (p IPoint) IsInYBound(height int) bool {
+	return p.Y >= 0 && p.Y < height
+}
+
+// IsInRange tests if the IPoint is in the range by X and Y axis.
+func (p IPoint) IsInRange(from, to int) bool {
+	return p.X >= from && p.X <= to && p.Y >= from && p.Y <= to
+}
+
+// IsInBound tests if the IPoint is in the positive bound by X and Y axis.
+func (p IPoint) IsInBound(width, height int) bool {
+	return p.X >= 0 && p.X < width && p.Y >= 0 && p.Y < height
+}
+
+// SymmetricX returns the symmetric IPoint by X axis in the grid of the width,
+// the cell 0 is mirrored to width-1.
+func (p IPoint) SymmetricX(width int) IPoint {
+	return IPoint{width - 1 - p.X, p.Y}
+}
+
+// SymmetricY returns the symmetric IPoint by Y axis in the grid of the height.
+func (p IPoint) SymmetricY(height int) IPoint {
+	return IPoint{p.X, height - 1 - p.Y}
+}
+
+// Symmetric returns the symmetric IPoint by X and Y axis in the grid of the size.
+func (p IPoint) Symmetric(width, height int) IPoint {
+	return IPoint{width - 1 - p.X, height - 1 - p.Y}
+}
+
+// Add returns the sum of two IPoints.
+func (p IPoint) Add(t IPoint) IPoint {
+	return IPoint{p.X + t.X, p.Y + t.Y}
+}
+
+// Sub returns the difference of two IPoints.
+func (p IPoint) Sub(t IPoint) IPoint {
+	return IPoint{p.X - t.X, p.Y - t.Y}
+}
+
+// Distance returns the distance between two IPoints using the Pythagorean theorem.
+func (p IPoint) Distance(t IPoint) float64 {
+	return math.Sqrt(float64(p.SquareDistance(t)))
+}
+
+// SquareDistance returns the square of the distance between two IPoints, exact in ints.
+func (p IPoint) SquareDistance(t IPoint) int {
+	return p.Sub(t).SquareLength()
+}
+
+// DistanceManhattan returns the Manhattan distance between two IPoints.
+// https://en.wikipedia.org/wiki/Taxicab_geometry
+func (p IPoint) DistanceManhattan(t IPoint) int {
+	return absInt(p.X-t.X) + absInt(p.Y-t.Y)
+}
+
+// DistanceChebyshev returns the Chebyshev distance between two IPoints.
+// https://en.wikipedia.org/wiki/Chebyshev_distance
+func (p IPoint) DistanceChebyshev(t IPoint) int {
+	dx, dy := absInt(p.X-t.X), absInt(p.Y-t.Y)
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
+
+// SquareLength returns the square length of the IPoint vector.
+func (p IPoint) SquareLength() int {
+	return p.X*p.X + p.Y*p.Y
+}
+
+// DotProduct returns the dot product of two IPoints vectors.
+func (p IPoint) DotProduct(t IPoint) int {
+	return p.X*t.X + p.Y*t.Y
+}
+
+// CrossProduct returns the cross product of two IPoints vectors.
+func (p IPoint) CrossProduct(t IPoint) int {
+	return p.X*t.Y - p.Y*t.X
+}
+
+// distanceToIBound returns the distance between x and the expected bound.
+func distanceToIBound(x, bound int) int {
+	if d := bound - x; d < x {
+		return d
+	}
+	return x
+}
+
+// DistanceToXBound returns the distance between the IPoint and the field bound of specified width.
+func (p IPoint) DistanceToXBound(bound int) int {
+	return distanceToIBound(p.X, bound)
+}
+
+// DistanceToYBound returns the distance between the IPoint and the field bound of specified height.
+func (p IPoint) DistanceToYBound(bound int) int {
+	return distanceToIBound(p.Y, bound)
+}
+
+// NeighborsCross returns the neighbors of the IPoint in the cross shape.
+func (p IPoint) NeighborsCross() IPoints {
+	return IPoints{
+		top:    {p.X, p.Y + 1},
+		right:  {p.X + 1, p.Y},
+		bottom: {p.X, p.Y - 1},
+		left:   {p.X - 1, p.Y},
+	}
+}
+
+// NeighborsAround returns the neighbors of the IPoint in the around shape.
+func (p IPoint) NeighborsAround() IPoints {
+	return IPoints{
+		top:         {p.X, p.Y + 1},
+		topRight:    {p.X + 1, p.Y + 1},
+		right:       {p.X + 1, p.Y},
+		bottomRight: {p.X + 1, p.Y - 1},
+		bottom:      {p.X, p.Y - 1},
+		bottomLeft:  {p.X - 1, p.Y - 1},
+		left:        {p.X - 1, p.Y},
+		topLeft:     {p.X - 1, p.Y + 1},
+	}
+}
+
+// Point returns the IPoint as Point.
+func (p IPoint) Point() Point {
+	return Point{float64(p.X), float64(p.Y)}
+}
+
+// IPoint returns the Point with the coordinates rounded to the nearest ints.
+func (p Point) IPoint() IPoint {
+	return IPoint{int(math.Round(p.X)), int(math.Round(p.Y))}
+}
+
+func (p IPoint) String() string {
+	return fmt.Sprintf("[X:%d,Y:%d]", p.X, p.Y)
+}
+
+// IPointFromIndex returns the IPoint of the 0-index in a field of specified width, the inverse of Index.
+func IPointFromIndex(index, width int) IPoint {
+	return IPoint{index % width, index / width}
+}
+
+// absInt returns the absolute value of x.
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+type IPoints []IPoint
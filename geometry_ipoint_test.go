@@ -0,0 +1,225 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPoint_IsSame(t *testing.T) {
+	p := IPoint{200, 100}
+	assert.True(t, p.IsSame(IPoint{200, 100}))
+	assert.False(t, p.IsSame(IPoint{100, 200}))
+}
+
+func TestIPoint_Index(t *testing.T) {
+	tests := []struct {
+		name  string
+		point IPoint
+		width int
+		want  int
+		panic bool
+	}{
+		{
+			name:  `first`,
+			point: IPoint{0, 0},
+			width: 10,
+			want:  0,
+		},
+		{
+			name:  `last in row`,
+			point: IPoint{9, 0},
+			width: 10,
+			want:  9,
+		},
+		{
+			name:  `next row`,
+			point: IPoint{0, 1},
+			width: 10,
+			want:  10,
+		},
+		{
+			name:  `last`,
+			point: IPoint{9, 9},
+			width: 10,
+			want:  99,
+		},
+		{
+			name:  `out of bound`,
+			point: IPoint{10, 0},
+			width: 10,
+			panic: true,
+		},
+		{
+			name:  `negative`,
+			point: IPoint{0, -1},
+			width: 10,
+			panic: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.panic {
+				assert.Panics(t, func() { tc.point.Index(tc.width) })
+				return
+			}
+			assert.Equal(t, tc.want, tc.point.Index(tc.width))
+			assert.Equal(t, tc.point, IPointFromIndex(tc.want, tc.width))
+		})
+	}
+}
+
+func TestIPoint_IsInRange(t *testing.T) {
+	p := IPoint{200, 100}
+	assert.True(t, p.IsInXRange(100, 300))
+	assert.True(t, p.IsInXRange(200, 200))
+	assert.False(t, p.IsInXRange(300, 500))
+	assert.True(t, p.IsInYRange(50, 150))
+	assert.False(t, p.IsInYRange(150, 250))
+	assert.True(t, p.IsInRange(100, 200))
+	assert.False(t, p.IsInRange(150, 250))
+}
+
+func TestIPoint_IsInBound(t *testing.T) {
+	p := IPoint{9, 4}
+	assert.True(t, p.IsInXBound(10))
+	assert.False(t, p.IsInXBound(9))
+	assert.True(t, p.IsInYBound(5))
+	assert.False(t, p.IsInYBound(4))
+	assert.True(t, p.IsInBound(10, 5))
+	assert.False(t, p.IsInBound(10, 4))
+	assert.False(t, IPoint{-1, 0}.IsInBound(10, 5))
+}
+
+func TestIPoint_Symmetric(t *testing.T) {
+	p := IPoint{200, 100}
+	assert.Equal(t, IPoint{799, 100}, p.SymmetricX(1000))
+	assert.Equal(t, IPoint{200, 899}, p.SymmetricY(1000))
+	assert.Equal(t, IPoint{799, 899}, p.Symmetric(1000, 1000))
+
+	// the corner cells stay in the grid
+	assert.Equal(t, IPoint{9, 4}, IPoint{0, 0}.Symmetric(10, 5))
+	assert.True(t, IPoint{0, 0}.SymmetricX(10).IsInXBound(10))
+	assert.Equal(t, IPoint{0, 0}, IPoint{9, 4}.Symmetric(10, 5))
+}
+
+func TestIPoint_AddSub(t *testing.T) {
+	p := IPoint{200, 100}
+	assert.Equal(t, IPoint{300, 300}, p.Add(IPoint{100, 200}))
+	assert.Equal(t, IPoint{100, -100}, p.Sub(IPoint{100, 200}))
+}
+
+func TestIPoint_Distance(t *testing.T) {
+	tests := []struct {
+		name      string
+		a, b      IPoint
+		want      float64
+		square    int
+		manhattan int
+		chebyshev int
+	}{
+		{
+			name:      `same`,
+			a:         IPoint{1, 1},
+			b:         IPoint{1, 1},
+			want:      0,
+			square:    0,
+			manhattan: 0,
+			chebyshev: 0,
+		},
+		{
+			name:      `triangle`,
+			a:         IPoint{0, 0},
+			b:         IPoint{3, 4},
+			want:      5,
+			square:    25,
+			manhattan: 7,
+			chebyshev: 4,
+		},
+		{
+			name:      `negative`,
+			a:         IPoint{2, 1},
+			b:         IPoint{-4, -1},
+			want:      6.324555320336759,
+			square:    40,
+			manhattan: 8,
+			chebyshev: 6,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, tc.a.Distance(tc.b), 1e-12)
+			assert.Equal(t, tc.square, tc.a.SquareDistance(tc.b))
+			assert.Equal(t, tc.manhattan, tc.a.DistanceManhattan(tc.b))
+			assert.Equal(t, tc.chebyshev, tc.a.DistanceChebyshev(tc.b))
+			// the same as of the Points
+			assert.Equal(t, tc.a.Point().Distance(tc.b.Point()), tc.a.Distance(tc.b))
+		})
+	}
+}
+
+func TestIPoint_Products(t *testing.T) {
+	p := IPoint{3, 4}
+	assert.Equal(t, 25, p.SquareLength())
+	assert.Equal(t, 11, p.DotProduct(IPoint{1, 2}))
+	assert.Equal(t, 2, p.CrossProduct(IPoint{1, 2}))
+}
+
+func TestIPoint_DistanceToBound(t *testing.T) {
+	p := IPoint{30, 80}
+	assert.Equal(t, 30, p.DistanceToXBound(100))
+	assert.Equal(t, 20, p.DistanceToYBound(100))
+}
+
+func TestIPoint_Neighbors(t *testing.T) {
+	p := IPoint{5, 5}
+	assert.Equal(t, IPoints{
+		top:    {5, 6},
+		right:  {6, 5},
+		bottom: {5, 4},
+		left:   {4, 5},
+	}, p.NeighborsCross())
+	assert.Equal(t, IPoints{
+		top:         {5, 6},
+		topRight:    {6, 6},
+		right:       {6, 5},
+		bottomRight: {6, 4},
+		bottom:      {5, 4},
+		bottomLeft:  {4, 4},
+		left:        {4, 5},
+		topLeft:     {4, 6},
+	}, p.NeighborsAround())
+}
+
+func TestIPoint_Convert(t *testing.T) {
+	p := IPoint{200, -100}
+	assert.Equal(t, Point{200, -100}, p.Point())
+	assert.Equal(t, p, p.Point().IPoint())
+	assert.Equal(t, IPoint{3, -3}, Point{2.5, -2.6}.IPoint())
+}
+
+func TestIPoint_String(t *testing.T) {
+	p := IPoint{200, 100}
+	assert.Equal(t, "[X:200,Y:100]", p.String())
+	assert.Equal(t, p.Point().String(), p.String())
+}
+
+// Benchmarks
+
+func BenchmarkIPoint_Index(b *testing.B) {
+	r := 0
+	for i := 0; i < b.N; i++ {
+		r = IPoint{i & 31, i >> 5 & 31}.Index(32)
+	}
+	GlobalI = r
+}
+
+func BenchmarkPoint_Index(b *testing.B) {
+	r := 0
+	for i := 0; i < b.N; i++ {
+		r = Point{float64(i & 31), float64(i >> 5 & 31)}.Index(32)
+	}
+	GlobalI = r
+}
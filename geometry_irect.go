@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+)
+
+// IRect represents a rectangle of the grid cells with the integer coordinates,
+// the bounds are inclusive, so IRect{0, 0, 0, 0} is a single cell.
+type IRect struct {
+	Xf, Xt, Yf, Yt int
+}
+
+// IsSame tests if two IRects are the same.
+func (r IRect) IsSame(t IRect) bool {
+	return r == t
+}
+
+// Width returns the number of the columns of the IRect.
+func (r IRect) Width() int {
+	return r.Xt - r.Xf + 1
+}
+
+// Height returns the number of the rows of the IRect.
+func (r IRect) Height() int {
+	return r.Yt - r.Yf + 1
+}
+
+// Area returns the number of the cells of the IRect.
+func (r IRect) Area() int {
+	return r.Width() * r.Height()
+}
+
+// Center returns the center IPoint of the IRect rounded down.
+func (r IRect) Center() IPoint {
+	return IPoint{
+		r.Xf + (r.Xt-r.Xf)/2,
+		r.Yf + (r.Yt-r.Yf)/2,
+	}
+}
+
+// Symmetric returns the symmetric IRect in the grid of the given width and height,
+// the mirrored cells keep the bounds ordered.
+func (r IRect) Symmetric(width, height int) IRect {
+	return IRect{
+		Xf: width - 1 - r.Xt,
+		Xt: width - 1 - r.Xf,
+		Yf: height - 1 - r.Yt,
+		Yt: height - 1 - r.Yf,
+	}
+}
+
+// IsContainsPoint tests if the IRect contains the IPoint.
+func (r IRect) IsContainsPoint(t IPoint) bool {
+	return t.X >= r.Xf && t.X <= r.Xt && t.Y >= r.Yf && t.Y <= r.Yt
+}
+
+// IsContainsRectangle tests if the IRect contains the other IRect.
+func (r IRect) IsContainsRectangle(t IRect) bool {
+	return r.Xf <= t.Xf && r.Xt >= t.Xt && r.Yf <= t.Yf && r.Yt >= t.Yt
+}
+
+// IsIntersectsRect tests if the IRect intersects the other IRect.
+func (r IRect) IsIntersectsRect(t IRect) bool {
+	return !(r.Xt < t.Xf || r.Xf > t.Xt || r.Yt < t.Yf || r.Yf > t.Yt)
+}
+
+// RectsIntersection returns the intersection IRect of two IRects, the common cells.
+func (r IRect) RectsIntersection(t IRect) (IRect, bool) {
+	if !r.IsIntersectsRect(t) {
+		return IRect{}, false
+	}
+
+	return IRect{
+		Xf: maxInt(r.Xf, t.Xf),
+		Xt: minInt(r.Xt, t.Xt),
+		Yf: maxInt(r.Yf, t.Yf),
+		Yt: minInt(r.Yt, t.Yt),
+	}, true
+}
+
+// Vertices returns the IPoints vertices of the IRect.
+func (r IRect) Vertices() IPoints {
+	return IPoints{
+		topLeft0:     {r.Xf, r.Yt},
+		topRight0:    {r.Xt, r.Yt},
+		bottomRight0: {r.Xt, r.Yf},
+		bottomLeft0:  {r.Xf, r.Yf},
+	}
+}
+
+// Edges returns the Lines edges of the IRect.
+func (r IRect) Edges() Lines {
+	return r.Rect().Edges()
+}
+
+// Rect returns the Rect of the same bounds, its Width is 1 less than of the IRect.
+func (r IRect) Rect() Rect {
+	return Rect{float64(r.Xf), float64(r.Xt), float64(r.Yf), float64(r.Yt)}
+}
+
+// IRect returns the Rect with the bounds rounded to the nearest ints.
+func (r Rect) IRect() IRect {
+	from, to := Point{r.Xf, r.Yf}.IPoint(), Point{r.Xt, r.Yt}.IPoint()
+	return IRect{from.X, to.X, from.Y, to.Y}
+}
+
+func (r IRect) String() string {
+	return fmt.Sprintf("[X:%d>%d,Y:%d>%d]", r.Xf, r.Xt, r.Yf, r.Yt)
+}
+
+func NewIRect(xf, xt, yf, yt int) IRect {
+	if xf > xt {
+		xf, xt = xt, xf
+	}
+	if yf > yt {
+		yf, yt = yt, yf
+	}
+
+	return IRect{
+		Xf: xf,
+		Xt: xt,
+		Yf: yf,
+		Yt: yt,
+	}
+}
+
+// minInt returns the smaller of the ints.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt returns the larger of the ints.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+type IRects []IRect
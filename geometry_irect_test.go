@@ -0,0 +1,169 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIRect_IsSame(t *testing.T) {
+	r := IRect{100, 200, 300, 400}
+	assert.True(t, r.IsSame(IRect{100, 200, 300, 400}))
+	assert.False(t, r.IsSame(IRect{300, 400, 100, 200}))
+}
+
+func TestIRect_Size(t *testing.T) {
+	r := IRect{100, 199, 300, 449}
+	assert.Equal(t, 100, r.Width())
+	assert.Equal(t, 150, r.Height())
+	assert.Equal(t, 15000, r.Area())
+}
+
+func TestIRect_Cell(t *testing.T) {
+	r := IRect{5, 5, 7, 7}
+	assert.Equal(t, 1, r.Width())
+	assert.Equal(t, 1, r.Height())
+	assert.Equal(t, 1, r.Area())
+	assert.Equal(t, IPoint{5, 7}, r.Center())
+	assert.True(t, r.IsContainsPoint(IPoint{5, 7}))
+	assert.False(t, r.IsContainsPoint(IPoint{6, 7}))
+
+	got, ok := r.RectsIntersection(IRect{0, 10, 0, 10})
+	assert.True(t, ok)
+	assert.Equal(t, r, got)
+}
+
+func TestIRect_Center(t *testing.T) {
+	assert.Equal(t, IPoint{150, 350}, IRect{100, 200, 300, 400}.Center())
+	// rounded down
+	assert.Equal(t, IPoint{4, 4}, IRect{0, 9, 0, 9}.Center())
+}
+
+func TestIRect_Symmetric(t *testing.T) {
+	r := IRect{100, 200, 300, 400}
+	assert.Equal(t, IRect{799, 899, 599, 699}, r.Symmetric(1000, 1000))
+
+	s := IRect{1, 3, 1, 3}.Symmetric(10, 10)
+	assert.Equal(t, IRect{6, 8, 6, 8}, s)
+	assert.Equal(t, 3, s.Width())
+	assert.Equal(t, 9, s.Area())
+	assert.True(t, s.IsContainsPoint(IPoint{8, 8}))
+	// the cells are mirrored one by one
+	assert.Equal(t, IPoint{1, 3}.Symmetric(10, 10), IPoint{s.Xt, s.Yf})
+}
+
+func TestIRect_IsContainsPoint(t *testing.T) {
+	r := IRect{100, 200, 300, 400}
+	assert.True(t, r.IsContainsPoint(IPoint{150, 350}))
+	assert.True(t, r.IsContainsPoint(IPoint{200, 400}))
+	assert.False(t, r.IsContainsPoint(IPoint{50, 250}))
+}
+
+func TestIRect_IsContainsRectangle(t *testing.T) {
+	r := IRect{100, 200, 300, 400}
+	assert.True(t, r.IsContainsRectangle(IRect{120, 180, 320, 380}))
+	assert.True(t, r.IsContainsRectangle(r))
+	assert.False(t, r.IsContainsRectangle(IRect{150, 250, 350, 450}))
+}
+
+func TestIRect_RectsIntersection(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b IRect
+		want IRect
+		ok   bool
+	}{
+		{
+			name: `overlap`,
+			a:    IRect{100, 200, 300, 400},
+			b:    IRect{150, 250, 350, 450},
+			want: IRect{150, 200, 350, 400},
+			ok:   true,
+		},
+		{
+			name: `inside`,
+			a:    IRect{100, 200, 300, 400},
+			b:    IRect{120, 180, 320, 380},
+			want: IRect{120, 180, 320, 380},
+			ok:   true,
+		},
+		{
+			name: `one column`,
+			a:    IRect{100, 200, 300, 400},
+			b:    IRect{200, 300, 300, 400},
+			want: IRect{200, 200, 300, 400},
+			ok:   true,
+		},
+		{
+			name: `one cell`,
+			a:    IRect{100, 200, 300, 400},
+			b:    IRect{200, 300, 400, 500},
+			want: IRect{200, 200, 400, 400},
+			ok:   true,
+		},
+		{
+			name: `adjacent`,
+			a:    IRect{100, 200, 300, 400},
+			b:    IRect{201, 300, 300, 400},
+		},
+		{
+			name: `apart`,
+			a:    IRect{100, 200, 300, 400},
+			b:    IRect{500, 600, 300, 400},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.a.RectsIntersection(tc.b)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.ok, tc.a.IsIntersectsRect(tc.b))
+			if ok {
+				assert.Equal(t, got.Area(), countCells(tc.a, tc.b))
+			}
+		})
+	}
+}
+
+// countCells returns the number of the cells in both IRects.
+func countCells(a, b IRect) int {
+	n := 0
+	for x := a.Xf; x <= a.Xt; x++ {
+		for y := a.Yf; y <= a.Yt; y++ {
+			if b.IsContainsPoint(IPoint{x, y}) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func TestIRect_Vertices(t *testing.T) {
+	r := IRect{100, 200, 300, 400}
+	assert.Equal(t, IPoints{
+		topLeft0:     {100, 400},
+		topRight0:    {200, 400},
+		bottomRight0: {200, 300},
+		bottomLeft0:  {100, 300},
+	}, r.Vertices())
+	assert.Equal(t, r.Rect().Edges(), r.Edges())
+}
+
+func TestIRect_Convert(t *testing.T) {
+	r := IRect{100, 200, -300, 400}
+	assert.Equal(t, Rect{100, 200, -300, 400}, r.Rect())
+	assert.Equal(t, r, r.Rect().IRect())
+	assert.Equal(t, IRect{0, 3, -3, 1}, Rect{0.4, 2.5, -2.6, 1}.IRect())
+}
+
+func TestIRect_String(t *testing.T) {
+	r := IRect{100, 200, 300, 400}
+	assert.Equal(t, "[X:100>200,Y:300>400]", r.String())
+	assert.Equal(t, r.Rect().String(), r.String())
+}
+
+func TestNewIRect(t *testing.T) {
+	assert.Equal(t, IRect{100, 200, 300, 400}, NewIRect(200, 100, 400, 300))
+	assert.Equal(t, IRect{100, 200, 300, 400}, NewIRect(100, 200, 300, 400))
+}
@@ -38,17 +38,17 @@ func (ln Line) CrossProduct() float64 {
 	return ln.From.X*ln.To.Y - ln.From.Y*ln.To.X
 }
 
-// IsHorizontal tests if the Line is horizontal.
+// IsHorizontal tests if the Line is horizontal within the tolerance.
 func (ln Line) IsHorizontal() bool {
-	return ln.From.Y == ln.To.Y
+	return tolerance.IsEqual(ln.From.Y, ln.To.Y)
 }
 
-// IsVertical tests if the Line is vertical.
+// IsVertical tests if the Line is vertical within the tolerance.
 func (ln Line) IsVertical() bool {
-	return ln.From.X == ln.To.X
+	return tolerance.IsEqual(ln.From.X, ln.To.X)
 }
 
-// IsMoving tests if the Line has magnitude.
+// IsMoving tests if the Line has magnitude beyond the tolerance.
 func (ln Line) IsMoving() bool {
 	return !ln.From.IsSame(ln.To)
 }
@@ -69,10 +69,13 @@ func (ln Line) Segment(length float64) Line {
 	}
 }
 
-// isPointOnLine tests if the Point is on the Line or Line segment.
+// isPointOnLine tests if the Point is on the Line or Line segment within the tolerance.
 func isPointOnLine(line Line, point Point, isSegment bool) bool {
 	lv := line.Vector()
 	pv := point.Sub(line.From)
+	if !tolerance.IsExact() {
+		return tolerance.isPointOnLine(lv, pv, isSegment)
+	}
 
 	pcp := lv.CrossProduct(pv)
 	if pcp != 0 {
@@ -99,6 +102,7 @@ func (ln Line) IsPointOnSegment(t Point) bool {
 
 // closestPoint returns the closest Point on the Line or Line segment to the given Point.
 func closestPoint(line Line, point Point, isSegment bool) Point {
+	invariant(line.IsMoving(), "closest point to zero length line")
 	nv := line.Vector().Normalize(line.Length())
 	dp := point.Sub(line.From).DotProduct(nv)
 
@@ -127,8 +131,13 @@ func (ln Line) ClosestPointToSegment(t Point) Point {
 	return closestPoint(ln, t, true)
 }
 
-// linesIntersection returns the intersection point of two Lines or Line segments.
+// linesIntersection returns the intersection point of two Lines or Line segments,
+// the Lines parallel within the tolerance do not intersect.
 func linesIntersection(lineA, lineB Line, isSegmentA, isSegmentB bool) (Point, bool) {
+	if !tolerance.IsExact() {
+		return tolerance.linesIntersection(lineA, lineB, isSegmentA, isSegmentB)
+	}
+
 	av := lineA.Vector()
 	bv := lineB.Vector()
 
@@ -171,15 +180,16 @@ func (ln Line) LineSegmentIntersection(t Line) (Point, bool) {
 	return linesIntersection(ln, t, false, true)
 }
 
-// Rotate returns the Line rotated by the given angle.
+// Rotate returns the Line rotated clockwise by the angle in degrees around From, the To is rounded.
 func (ln Line) Rotate(angle float64) Line {
-	radians := angle * math.Pi / 180
-
-	v := ln.Vector()
-	rx := v.X*math.Cos(radians) + v.Y*math.Sin(radians)
-	ry := v.X*-math.Sin(radians) + v.Y*math.Cos(radians)
+	to := ln.From.Add(ln.Vector().Rotate(angle))
+	return Line{From: ln.From, To: NewPoint(to.X, to.Y)}
+}
 
-	return Line{From: ln.From, To: NewPoint(ln.From.X+rx, ln.From.Y+ry)}
+// RotateDeg is the Rotate by the integer angle using the precomputed table.
+func (ln Line) RotateDeg(angle int) Line {
+	to := ln.From.Add(ln.Vector().RotateDeg(angle))
+	return Line{From: ln.From, To: NewPoint(to.X, to.Y)}
 }
 
 // IsCollision tests whether a moving object collides with another moving object within a given radius.
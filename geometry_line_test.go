@@ -571,6 +571,13 @@ func TestLine_Rotate(t *testing.T) {
 	}
 }
 
+func TestLine_RotateDeg(t *testing.T) {
+	line := Line{Point{500, 500}, Point{1000, 700}}
+	for angle := -360; angle <= 360; angle += 5 {
+		assert.Equal(t, line.Rotate(float64(angle)), line.RotateDeg(angle), angle)
+	}
+}
+
 func TestLine_IsCollision(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -712,3 +719,21 @@ func BenchmarkLine_IsCollision(b *testing.B) {
 	}
 	GlobalB = r
 }
+
+func BenchmarkLine_Rotate(b *testing.B) {
+	r := Line{}
+	ln := Line{Point{500, 500}, Point{1000, 700}}
+	for i := 0; i < b.N; i++ {
+		r = ln.Rotate(float64(i%360 - 180))
+	}
+	GlobalLine = r
+}
+
+func BenchmarkLine_RotateDeg(b *testing.B) {
+	r := Line{}
+	ln := Line{Point{500, 500}, Point{1000, 700}}
+	for i := 0; i < b.N; i++ {
+		r = ln.RotateDeg(i%360 - 180)
+	}
+	GlobalLine = r
+}
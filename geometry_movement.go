@@ -18,6 +18,21 @@ func MovingDistance(speed, acceleration, time float64) float64 {
 	return (speed * time) + (0.5 * acceleration * time * time)
 }
 
+// MovingTime calculates the time to travel the distance, the inverse of MovingDistance.
+// It returns false when the distance is never reached.
+func MovingTime(speed, acceleration, distance float64) (float64, bool) {
+	if distance == 0 {
+		return 0, true
+	}
+	roots, n := SolveQuadratic(0.5*acceleration, speed, -distance)
+	for _, t := range roots[:n] {
+		if t >= 0 {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
 // MovingVector calculates the vector of a moving object with static angle coordinate system.
 // see https://www.codingame.com/training/easy/mars-lander-episode-1
 func MovingVector(angle, power float64) Point {
@@ -28,3 +43,13 @@ func MovingVector(angle, power float64) Point {
 		Y: power * math.Cos(rad),
 	}
 }
+
+// MovingVectorDeg is the MovingVector of the integer angle using the precomputed table.
+func MovingVectorDeg(angle int, power float64) Point {
+	sin, cos := degrees.SinCosDeg(angle)
+
+	return Point{
+		X: -power * sin,
+		Y: power * cos,
+	}
+}
@@ -42,6 +42,70 @@ func TestMovingDistance(t *testing.T) {
 	}
 }
 
+func TestMovingTime(t *testing.T) {
+	tests := []struct {
+		name  string
+		speed float64
+		acc   float64
+		dist  float64
+		want  float64
+		ok    bool
+	}{
+		{
+			name:  `speed`,
+			speed: 10,
+			dist:  50,
+			want:  5,
+			ok:    true,
+		},
+		{
+			name: `acc`,
+			acc:  10,
+			dist: 125,
+			want: 5,
+			ok:   true,
+		},
+		{
+			name:  `speed and acc`,
+			speed: 10,
+			acc:   10,
+			dist:  175,
+			want:  5,
+			ok:    true,
+		},
+		{
+			name:  `braking`,
+			speed: 10,
+			acc:   -2,
+			dist:  25,
+			want:  5,
+			ok:    true,
+		},
+		{
+			name:  `stops before`,
+			speed: 10,
+			acc:   -2,
+			dist:  26,
+		},
+		{
+			name: `zero`,
+			dist: 0,
+			ok:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := MovingTime(tc.speed, tc.acc, tc.dist)
+			assert.Equal(t, tc.ok, ok)
+			assert.InDelta(t, tc.want, got, 1e-9)
+			if ok {
+				assert.InDelta(t, tc.dist, MovingDistance(tc.speed, tc.acc, got), 1e-9)
+			}
+		})
+	}
+}
+
 func TestMovingVector(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -95,3 +159,30 @@ func TestMovingVector(t *testing.T) {
 		})
 	}
 }
+
+func TestMovingVectorDeg(t *testing.T) {
+	for angle := -360; angle <= 360; angle++ {
+		want := MovingVector(float64(angle), 4)
+		mv := MovingVectorDeg(angle, 4)
+		assert.InDelta(t, want.X, mv.X, 1e-14, angle)
+		assert.InDelta(t, want.Y, mv.Y, 1e-14, angle)
+	}
+}
+
+// Benchmarks
+
+func BenchmarkMovingVector(b *testing.B) {
+	p := Point{}
+	for i := 0; i < b.N; i++ {
+		p = MovingVector(float64(i%180-90), 4)
+	}
+	GlobalPoint = p
+}
+
+func BenchmarkMovingVectorDeg(b *testing.B) {
+	p := Point{}
+	for i := 0; i < b.N; i++ {
+		p = MovingVectorDeg(i%180-90, 4)
+	}
+	GlobalPoint = p
+}
@@ -30,9 +30,9 @@ type Point struct {
 	X, Y float64
 }
 
-// IsSame tests if two Points are the same.
+// IsSame tests if two Points are the same within the tolerance.
 func (p Point) IsSame(t Point) bool {
-	return p.X == t.X && p.Y == t.Y
+	return tolerance.IsEqual(p.X, t.X) && tolerance.IsEqual(p.Y, t.Y)
 }
 
 // Index returns the 0-index of the Point in a field of specified width.
@@ -43,9 +43,9 @@ func (p Point) Index(width float64) int {
 	return int(p.Y*width + p.X)
 }
 
-// IsInXRange tests if the Point is in the range by X axis.
+// IsInXRange tests if the Point is in the range by X axis within the tolerance.
 func (p Point) IsInXRange(from, to float64) bool {
-	return p.X >= from && p.X <= to
+	return tolerance.IsLessOrEqual(from, p.X) && tolerance.IsLessOrEqual(p.X, to)
 }
 
 // IsInXBound tests if the Point is in the field defined by width.
@@ -53,9 +53,9 @@ func (p Point) IsInXBound(width float64) bool {
 	return p.X >= 0 && p.X < width
 }
 
-// IsInYRange tests if the Point is in the range by Y axis.
+// IsInYRange tests if the Point is in the range by Y axis within the tolerance.
 func (p Point) IsInYRange(from, to float64) bool {
-	return p.Y >= from && p.Y <= to
+	return tolerance.IsLessOrEqual(from, p.Y) && tolerance.IsLessOrEqual(p.Y, to)
 }
 
 // IsInYBound tests if the Point is in the field defined by height.
@@ -63,9 +63,9 @@ func (p Point) IsInYBound(height float64) bool {
 	return p.Y >= 0 && p.Y < height
 }
 
-// IsInRange tests if the Point is in the range by X and Y axis.
+// IsInRange tests if the Point is in the range by X and Y axis within the tolerance.
 func (p Point) IsInRange(from, to float64) bool {
-	return p.X >= from && p.X <= to && p.Y >= from && p.Y <= to
+	return p.IsInXRange(from, to) && p.IsInYRange(from, to)
 }
 
 // IsInBound tests if the Point is in the positive bound by X and Y axis.
@@ -134,6 +134,7 @@ func (p Point) CrossProduct(t Point) float64 {
 
 // Normalize returns the normalized Point vector of specified length.
 func (p Point) Normalize(length float64) Point {
+	invariant(length != 0, "normalize by zero length")
 	return Point{p.X / length, p.Y / length}
 }
 
@@ -10,9 +10,10 @@ type Rect struct {
 	Xf, Xt, Yf, Yt float64
 }
 
-// IsSame tests if two Rects are the same.
+// IsSame tests if two Rects are the same within the tolerance.
 func (r Rect) IsSame(t Rect) bool {
-	return r.Xf == t.Xf && r.Xt == t.Xt && r.Yf == t.Yf && r.Yt == t.Yt
+	return tolerance.IsEqual(r.Xf, t.Xf) && tolerance.IsEqual(r.Xt, t.Xt) &&
+		tolerance.IsEqual(r.Yf, t.Yf) && tolerance.IsEqual(r.Yt, t.Yt)
 }
 
 // Width returns the width of the Rect.
@@ -48,19 +49,21 @@ func (r Rect) Symmetric(width, height float64) Rect {
 	}
 }
 
-// IsContainsPoint tests if the Rect contains the Point.
+// IsContainsPoint tests if the Rect contains the Point within the tolerance.
 func (r Rect) IsContainsPoint(t Point) bool {
-	return t.X >= r.Xf && t.X <= r.Xt && t.Y >= r.Yf && t.Y <= r.Yt
+	return t.IsInXRange(r.Xf, r.Xt) && t.IsInYRange(r.Yf, r.Yt)
 }
 
-// IsContainsRectangle tests if the Rect contains the other Rect.
+// IsContainsRectangle tests if the Rect contains the other Rect within the tolerance.
 func (r Rect) IsContainsRectangle(t Rect) bool {
-	return r.Xf <= t.Xf && r.Xt >= t.Xt && r.Yf <= t.Yf && r.Yt >= t.Yt
+	return tolerance.IsLessOrEqual(r.Xf, t.Xf) && tolerance.IsLessOrEqual(t.Xt, r.Xt) &&
+		tolerance.IsLessOrEqual(r.Yf, t.Yf) && tolerance.IsLessOrEqual(t.Yt, r.Yt)
 }
 
-// IsIntersectsRect tests if the Rect intersects the other Rect.
+// IsIntersectsRect tests if the Rect intersects or touches the other Rect within the tolerance.
 func (r Rect) IsIntersectsRect(t Rect) bool {
-	return !(r.Xt < t.Xf || r.Xf > t.Xt || r.Yt < t.Yf || r.Yf > t.Yt)
+	return tolerance.IsLessOrEqual(t.Xf, r.Xt) && tolerance.IsLessOrEqual(r.Xf, t.Xt) &&
+		tolerance.IsLessOrEqual(t.Yf, r.Yt) && tolerance.IsLessOrEqual(r.Yf, t.Yt)
 }
 
 // RectsIntersection returns the intersection Rect of two Rects.
@@ -75,7 +78,7 @@ func (r Rect) RectsIntersection(t Rect) (Rect, bool) {
 		math.Max(r.Yf, t.Yf),
 		math.Min(r.Yt, t.Yt),
 	)
-	if ir.Width() == 0 || ir.Height() == 0 {
+	if tolerance.IsEqual(ir.Width(), 0) || tolerance.IsEqual(ir.Height(), 0) {
 		return Rect{}, false
 	}
 
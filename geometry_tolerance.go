@@ -0,0 +1,106 @@
+package main
+
+// The float comparisons of the geometry predicates.
+// The integer games compare the coordinates exactly, the games with rotations,
+// normalized vectors or speeds set the tolerance once in init:
+//
+//	tolerance = Tolerance{Abs: 1e-9, Rel: 1e-12}
+
+import (
+	"math"
+)
+
+// tolerance is the Tolerance of the geometry predicates, exact by default.
+var tolerance Tolerance
+
+// Tolerance is the policy of the float comparisons, the zero value is the exact mode.
+type Tolerance struct {
+	// Abs is the absolute delta of the coordinates and the distances.
+	Abs float64
+	// Rel is the delta relative to the magnitude of the compared values.
+	Rel float64
+}
+
+// IsExact tests if the values are compared exactly.
+func (t Tolerance) IsExact() bool {
+	return t.Abs == 0 && t.Rel == 0
+}
+
+// IsEqual tests if the values are equal within the absolute or the relative delta.
+func (t Tolerance) IsEqual(a, b float64) bool {
+	return a == b || !t.IsExact() && t.isNear(a, b)
+}
+
+// IsLessOrEqual tests if a is less than or equal to b within the tolerance.
+func (t Tolerance) IsLessOrEqual(a, b float64) bool {
+	return a <= b || t.IsEqual(a, b)
+}
+
+// isNear compares the values within the deltas, the exact mode is checked by IsEqual.
+func (t Tolerance) isNear(a, b float64) bool {
+	return IsFloatsEqual(a, b, math.Max(t.Abs, t.Rel*math.Max(math.Abs(a), math.Abs(b))))
+}
+
+// The predicates with the tolerance set, the exact mode keeps the plain comparisons
+// of the predicates in geometry_line.go and never computes the deltas.
+
+// isPointOnLine tests if the end of the vector pv is on the Line of the vector lv within the tolerance.
+func (t Tolerance) isPointOnLine(lv, pv Point, isSegment bool) bool {
+	delta := t.productDelta(lv, pv)
+
+	pcp := lv.CrossProduct(pv)
+	if !IsFloatsEqual(pcp, 0, delta) {
+		return false
+	}
+
+	if isSegment {
+		dp := pv.DotProduct(lv)
+		return dp >= -delta && dp <= lv.SquareLength()+delta
+	}
+
+	return true
+}
+
+// linesIntersection returns the intersection point of two Lines or Line segments within the tolerance,
+// the Lines parallel within the tolerance do not intersect.
+func (t Tolerance) linesIntersection(lineA, lineB Line, isSegmentA, isSegmentB bool) (Point, bool) {
+	av := lineA.Vector()
+	bv := lineB.Vector()
+
+	vcp := av.CrossProduct(bv)
+	if IsFloatsEqual(vcp, 0, t.productDelta(av, bv)) {
+		return Point{}, false
+	}
+
+	sv := lineB.From.Sub(lineA.From)
+	sa := sv.CrossProduct(bv) / vcp
+	sb := sv.CrossProduct(av) / vcp
+
+	if isSegmentA {
+		if delta := t.paramDelta(av); sa < -delta || sa > 1+delta {
+			return Point{}, false
+		}
+	}
+	if isSegmentB {
+		if delta := t.paramDelta(bv); sb < -delta || sb > 1+delta {
+			return Point{}, false
+		}
+	}
+
+	return Point{
+		X: lineA.From.X + sa*av.X,
+		Y: lineA.From.Y + sa*av.Y,
+	}, true
+}
+
+// productDelta returns the delta of the cross or the dot product of the vectors v and w:
+// the absolute delta is the distance along v, the relative one is of the product of the lengths.
+func (t Tolerance) productDelta(v, w Point) float64 {
+	lv := math.Sqrt(v.SquareLength())
+	return math.Max(t.Abs*lv, t.Rel*lv*math.Sqrt(w.SquareLength()))
+}
+
+// paramDelta returns the delta of the parameter s of the point From + s*v on the Line of the vector v.
+func (t Tolerance) paramDelta(v Point) float64 {
+	return math.Max(t.Abs/math.Sqrt(v.SquareLength()), t.Rel)
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rounded is 0.1 + 0.2 in float64, the constant expression is exact.
+var rounded = 0.30000000000000004
+
+// withTolerance sets the tolerance of the geometry predicates for the test.
+func withTolerance(t *testing.T, tol Tolerance) {
+	old := tolerance
+	tolerance = tol
+	t.Cleanup(func() {
+		tolerance = old
+	})
+}
+
+func TestTolerance_IsEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		tol  Tolerance
+		a, b float64
+		want bool
+	}{
+		{
+			name: `exact same`,
+			a:    0.3, b: 0.3,
+			want: true,
+		},
+		{
+			name: `exact rounding`,
+			a:    rounded, b: 0.3,
+			want: false,
+		},
+		{
+			name: `exact inf`,
+			a:    math.Inf(1), b: math.Inf(1),
+			want: true,
+		},
+		{
+			name: `abs rounding`,
+			tol:  Tolerance{Abs: 1e-9},
+			a:    rounded, b: 0.3,
+			want: true,
+		},
+		{
+			name: `abs far`,
+			tol:  Tolerance{Abs: 1e-9},
+			a:    0.3, b: 0.3001,
+			want: false,
+		},
+		{
+			name: `abs large`,
+			tol:  Tolerance{Abs: 1e-9},
+			a:    1e10, b: 1e10 + 1e-5,
+			want: false,
+		},
+		{
+			name: `rel large`,
+			tol:  Tolerance{Rel: 1e-12},
+			a:    1e10, b: 1e10 + 1e-5,
+			want: true,
+		},
+		{
+			name: `rel zero`,
+			tol:  Tolerance{Rel: 1e-12},
+			a:    1e-20, b: 0,
+			want: false,
+		},
+		{
+			name: `nan`,
+			tol:  Tolerance{Abs: 1},
+			a:    math.NaN(), b: math.NaN(),
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.tol.IsEqual(tc.a, tc.b))
+		})
+	}
+}
+
+func TestTolerance_IsLessOrEqual(t *testing.T) {
+	assert.True(t, Tolerance{}.IsLessOrEqual(1, 2))
+	assert.False(t, Tolerance{}.IsLessOrEqual(rounded, 0.3))
+	assert.True(t, Tolerance{Abs: 1e-9}.IsLessOrEqual(rounded, 0.3))
+	assert.False(t, Tolerance{Abs: 1e-9}.IsLessOrEqual(2, 1))
+}
+
+func TestTolerance_Point(t *testing.T) {
+	p := Point{rounded, 0.7}
+	assert.False(t, p.IsSame(Point{0.3, 0.7}))
+	assert.False(t, p.IsInXRange(0, 0.3))
+
+	withTolerance(t, Tolerance{Abs: 1e-9})
+	assert.True(t, p.IsSame(Point{0.3, 0.7}))
+	assert.False(t, p.IsSame(Point{0.3, 0.71}))
+	assert.True(t, p.IsInXRange(0, 0.3))
+	assert.True(t, p.IsInRange(0.3, 0.7))
+	assert.True(t, Rect{0, 0.3, 0, 1}.IsContainsPoint(p))
+	assert.True(t, Line{Point{0.3, 0}, Point{rounded, 10}}.IsVertical())
+}
+
+func TestTolerance_PointOnLine(t *testing.T) {
+	line := Line{Point{0, 0}, Point{3, math.Sqrt(3)}}
+	// near-collinear by the rounding of the coordinates
+	near := Point{1.5, math.Sqrt(3)/2 + 1e-12}
+	far := Point{1.5, math.Sqrt(3)/2 + 1e-6}
+	after := Point{3 + 1e-12, math.Sqrt(3)}
+
+	assert.False(t, line.IsPointOnLine(near))
+	assert.False(t, line.IsPointOnSegment(after))
+
+	withTolerance(t, Tolerance{Abs: 1e-9})
+	assert.True(t, line.IsPointOnLine(near))
+	assert.True(t, line.IsPointOnSegment(near))
+	assert.False(t, line.IsPointOnLine(far))
+	assert.True(t, line.IsPointOnSegment(after))
+	assert.False(t, line.IsPointOnSegment(Point{3.3, math.Sqrt(3) * 1.1}))
+}
+
+func TestTolerance_PointOnRotatedLine(t *testing.T) {
+	line := Line{Point{100, 100}, Point{1100, 100}}.Rotate(30)
+	mid := Point{(line.From.X + line.To.X) / 2, (line.From.Y + line.To.Y) / 2}
+
+	withTolerance(t, Tolerance{Rel: 1e-12})
+	assert.True(t, line.IsPointOnSegment(mid))
+	assert.True(t, line.IsPointOnSegment(line.To))
+	assert.False(t, line.IsPointOnSegment(mid.Add(Point{0, 1e-3})))
+}
+
+func TestTolerance_LinesIntersection(t *testing.T) {
+	a := Line{Point{0, 0}, Point{10, 10}}
+	// near-parallel by the rounding
+	b := Line{Point{0, 1}, Point{10, 11 + 1e-13}}
+
+	_, ok := a.LinesIntersection(b)
+	assert.True(t, ok)
+
+	withTolerance(t, Tolerance{Abs: 1e-9})
+	_, ok = a.LinesIntersection(b)
+	assert.False(t, ok)
+
+	// skewed beyond the tolerance
+	_, ok = a.LinesIntersection(Line{Point{0, 1}, Point{10, 12}})
+	assert.True(t, ok)
+
+	// the segments touching at the end within the tolerance
+	c := Line{Point{10 + 1e-12, 0}, Point{10 + 1e-12, 20}}
+	got, ok := a.SegmentsIntersection(c)
+	assert.True(t, ok)
+	assert.InDelta(t, 10, got.X, 1e-9)
+	assert.InDelta(t, 10, got.Y, 1e-9)
+
+	_, ok = a.SegmentsIntersection(Line{Point{10.1, 0}, Point{10.1, 20}})
+	assert.False(t, ok)
+}
+
+func TestTolerance_Rect(t *testing.T) {
+	r := Rect{0, 0.3, 0, 1}
+	touching := Rect{rounded, 1, 0, 1}
+	assert.False(t, r.IsSame(Rect{0, rounded, 0, 1}))
+
+	withTolerance(t, Tolerance{Abs: 1e-9})
+	assert.True(t, r.IsSame(Rect{0, rounded, 0, 1}))
+	assert.True(t, r.IsIntersectsRect(touching))
+	_, ok := r.RectsIntersection(touching)
+	assert.False(t, ok)
+	assert.True(t, Rect{0, rounded, 0, 1}.IsContainsRectangle(r))
+}
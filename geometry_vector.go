@@ -0,0 +1,88 @@
+package main
+
+// The vector algebra of Point.
+// The results are not rounded, unlike the NewPoint constructor,
+// Round rounds the coordinates explicitly when the game needs the integers.
+// The positive angles turn clockwise in the Y-up coordinates like Line.Rotate.
+
+import (
+	"math"
+)
+
+// Length returns the length of the Point vector.
+func (p Point) Length() float64 {
+	return math.Sqrt(p.SquareLength())
+}
+
+// Scale returns the Point vector multiplied by k.
+func (p Point) Scale(k float64) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+// Unit returns the Point vector of the length 1.
+func (p Point) Unit() Point {
+	return p.Normalize(p.Length())
+}
+
+// Rotate returns the Point vector rotated by the angle in degrees.
+func (p Point) Rotate(angle float64) Point {
+	return p.RotateRad(angle * math.Pi / 180)
+}
+
+// RotateRad returns the Point vector rotated by the angle in radians.
+func (p Point) RotateRad(angle float64) Point {
+	sin, cos := math.Sincos(angle)
+	return p.rotate(sin, cos)
+}
+
+// RotateDeg is the Rotate by the integer angle using the precomputed table.
+func (p Point) RotateDeg(angle int) Point {
+	sin, cos := degrees.SinCosDeg(angle)
+	return p.rotate(sin, cos)
+}
+
+func (p Point) rotate(sin, cos float64) Point {
+	return Point{
+		X: p.X*cos + p.Y*sin,
+		Y: -p.X*sin + p.Y*cos,
+	}
+}
+
+// Perp returns the Point vector rotated by 90 degrees, the same as Rotate(90).
+func (p Point) Perp() Point {
+	return Point{p.Y, -p.X}
+}
+
+// ProjectOnto returns the projection of the Point vector onto the vector t.
+func (p Point) ProjectOnto(t Point) Point {
+	sl := t.SquareLength()
+	invariant(sl != 0, "project onto zero vector")
+	return t.Scale(p.DotProduct(t) / sl)
+}
+
+// Reflect returns the Point vector reflected from the surface with the normal vector of any length.
+func (p Point) Reflect(normal Point) Point {
+	sl := normal.SquareLength()
+	invariant(sl != 0, "reflect by zero normal")
+	return p.Sub(normal.Scale(2 * p.DotProduct(normal) / sl))
+}
+
+// AngleTo returns the angle in degrees in (-180, 180] to Rotate the Point vector to the direction of t.
+func (p Point) AngleTo(t Point) float64 {
+	angle := math.Atan2(-p.CrossProduct(t), p.DotProduct(t)) * (180 / math.Pi)
+	// the opposite vectors give -180 by the negative zero of the cross product
+	if angle == -180 {
+		return 180
+	}
+	return angle
+}
+
+// Lerp returns the linear interpolation between two Points, s of 0 is the Point and 1 is t.
+func (p Point) Lerp(t Point, s float64) Point {
+	return Point{Lerp(p.X, t.X, s), Lerp(p.Y, t.Y, s)}
+}
+
+// Round returns the Point with the coordinates rounded to the nearest integers like NewPoint.
+func (p Point) Round() Point {
+	return NewPoint(p.X, p.Y)
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertPoint asserts the Point within the rounding of the float math.
+func assertPoint(t *testing.T, want, got Point) {
+	t.Helper()
+	assert.InDelta(t, want.X, got.X, 1e-9, "X of %s", got)
+	assert.InDelta(t, want.Y, got.Y, 1e-9, "Y of %s", got)
+}
+
+func TestPoint_Length(t *testing.T) {
+	assert.Equal(t, 5.0, Point{3, 4}.Length())
+	assert.Equal(t, 5.0, Point{-3, -4}.Length())
+	assert.Equal(t, 0.0, Point{}.Length())
+}
+
+func TestPoint_Scale(t *testing.T) {
+	assert.Equal(t, Point{1.5, -2}, Point{3, -4}.Scale(0.5))
+	assert.Equal(t, Point{}, Point{3, -4}.Scale(0))
+}
+
+func TestPoint_Unit(t *testing.T) {
+	assert.Equal(t, Point{0.6, 0.8}, Point{3, 4}.Unit())
+	assert.InDelta(t, 1, Point{1, 1}.Unit().Length(), 1e-15)
+}
+
+func TestPoint_Unit_Zero(t *testing.T) {
+	if !debugBuild {
+		t.Skip("invariants are off")
+	}
+	assert.Panics(t, func() { Point{}.Unit() })
+	assert.Panics(t, func() { Point{1, 1}.ProjectOnto(Point{}) })
+	assert.Panics(t, func() { Point{1, 1}.Reflect(Point{}) })
+}
+
+func TestPoint_Rotate(t *testing.T) {
+	tests := []struct {
+		name  string
+		point Point
+		angle int
+		want  Point
+	}{
+		{
+			name:  `zero`,
+			point: Point{10, 0},
+			angle: 0,
+			want:  Point{10, 0},
+		},
+		{
+			name:  `cw 90`,
+			point: Point{10, 0},
+			angle: 90,
+			want:  Point{0, -10},
+		},
+		{
+			name:  `ccw 90`,
+			point: Point{10, 0},
+			angle: -90,
+			want:  Point{0, 10},
+		},
+		{
+			name:  `back`,
+			point: Point{10, 5},
+			angle: angleBack,
+			want:  Point{-10, -5},
+		},
+		{
+			name:  `cw 45`,
+			point: Point{10, 0},
+			angle: 45,
+			want:  Point{5 * math.Sqrt2, -5 * math.Sqrt2},
+		},
+		{
+			name:  `full turn`,
+			point: Point{3, 4},
+			angle: 360 + 30,
+			want:  Point{3, 4}.Rotate(30),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assertPoint(t, tc.want, tc.point.Rotate(float64(tc.angle)))
+			assertPoint(t, tc.want, tc.point.RotateRad(float64(tc.angle)*math.Pi/180))
+			assertPoint(t, tc.want, tc.point.RotateDeg(tc.angle))
+			assert.InDelta(t, tc.point.Length(), tc.point.Rotate(float64(tc.angle)).Length(), 1e-9)
+		})
+	}
+}
+
+func TestPoint_Rotate_Line(t *testing.T) {
+	// the same direction as the Line rotated around From
+	line := Line{Point{500, 500}, Point{1000, 700}}
+	for angle := -360; angle <= 360; angle += 15 {
+		to := line.From.Add(line.Vector().Rotate(float64(angle)))
+		assert.Equal(t, line.Rotate(float64(angle)).To, to.Round(), angle)
+		assert.Equal(t, line.RotateDeg(angle).To, line.From.Add(line.Vector().RotateDeg(angle)).Round(), angle)
+	}
+}
+
+func TestPoint_Rotate_MovingVector(t *testing.T) {
+	// the thrust angles turn counterclockwise
+	for angle := -90; angle <= 90; angle += 15 {
+		assertPoint(t, MovingVector(float64(angle), 4), Point{0, 4}.Rotate(float64(-angle)))
+	}
+}
+
+func TestPoint_Perp(t *testing.T) {
+	p := Point{3, 4}
+	assert.Equal(t, Point{4, -3}, p.Perp())
+	assert.Equal(t, 0.0, p.DotProduct(p.Perp()))
+	assertPoint(t, p.Rotate(90), p.Perp())
+}
+
+func TestPoint_ProjectOnto(t *testing.T) {
+	tests := []struct {
+		name  string
+		point Point
+		onto  Point
+		want  Point
+	}{
+		{
+			name:  `axis`,
+			point: Point{3, 4},
+			onto:  Point{10, 0},
+			want:  Point{3, 0},
+		},
+		{
+			name:  `opposite`,
+			point: Point{-3, 4},
+			onto:  Point{1, 0},
+			want:  Point{-3, 0},
+		},
+		{
+			name:  `diagonal`,
+			point: Point{2, 0},
+			onto:  Point{1, 1},
+			want:  Point{1, 1},
+		},
+		{
+			name:  `perpendicular`,
+			point: Point{0, 5},
+			onto:  Point{2, 0},
+			want:  Point{0, 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assertPoint(t, tc.want, tc.point.ProjectOnto(tc.onto))
+		})
+	}
+}
+
+func TestPoint_Reflect(t *testing.T) {
+	tests := []struct {
+		name   string
+		point  Point
+		normal Point
+		want   Point
+	}{
+		{
+			name:   `floor`,
+			point:  Point{3, -4},
+			normal: Point{0, 1},
+			want:   Point{3, 4},
+		},
+		{
+			name:   `long normal`,
+			point:  Point{3, -4},
+			normal: Point{0, 10},
+			want:   Point{3, 4},
+		},
+		{
+			name:   `wall`,
+			point:  Point{3, -4},
+			normal: Point{-1, 0},
+			want:   Point{-3, -4},
+		},
+		{
+			name:   `diagonal`,
+			point:  Point{1, 0},
+			normal: Point{-1, 1},
+			want:   Point{0, 1},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.point.Reflect(tc.normal)
+			assertPoint(t, tc.want, got)
+			assert.InDelta(t, tc.point.Length(), got.Length(), 1e-9)
+		})
+	}
+}
+
+func TestPoint_AngleTo(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Point
+		want float64
+	}{
+		{
+			name: `same`,
+			a:    Point{1, 0},
+			b:    Point{5, 0},
+			want: 0,
+		},
+		{
+			name: `cw`,
+			a:    Point{1, 0},
+			b:    Point{0, -2},
+			want: 90,
+		},
+		{
+			name: `ccw`,
+			a:    Point{1, 0},
+			b:    Point{0, 2},
+			want: -90,
+		},
+		{
+			name: `back`,
+			a:    Point{1, 0},
+			b:    Point{-1, 0},
+			want: 180,
+		},
+		{
+			name: `diagonal`,
+			a:    Point{0, 1},
+			b:    Point{1, 1},
+			want: 45,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, tc.a.AngleTo(tc.b), 1e-9)
+			assertPoint(t, tc.b.Unit(), tc.a.Unit().Rotate(tc.a.AngleTo(tc.b)))
+		})
+	}
+}
+
+func TestPoint_Lerp(t *testing.T) {
+	a, b := Point{0, 10}, Point{10, 20}
+	assert.Equal(t, a, a.Lerp(b, 0))
+	assert.Equal(t, b, a.Lerp(b, 1))
+	assert.Equal(t, Point{2.5, 12.5}, a.Lerp(b, 0.25))
+}
+
+func TestPoint_Round(t *testing.T) {
+	p := Point{10, 0}.Rotate(-30)
+	// unrounded unlike the constructors
+	assert.InDelta(t, 8.660254037844386, p.X, 1e-12)
+	assert.Equal(t, Point{9, 5}, p.Round())
+	assert.Equal(t, Point{-3, 3}, Point{-2.5, 2.5}.Round())
+}
+
+// Benchmarks
+
+func BenchmarkPoint_Rotate(b *testing.B) {
+	p := Point{10, 0}
+	for i := 0; i < b.N; i++ {
+		p = p.Rotate(float64(i % 360))
+	}
+	GlobalPoint = p
+}
+
+func BenchmarkPoint_RotateDeg(b *testing.B) {
+	p := Point{10, 0}
+	for i := 0; i < b.N; i++ {
+		p = p.RotateDeg(i % 360)
+	}
+	GlobalPoint = p
+}
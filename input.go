@@ -24,6 +24,9 @@ func InputGame(data []string) Game {
 	var size int
 	size = StrToInt(data[0])
 	data = data[1:]
+	if debugBuild {
+		invariant(len(data) == size, "expected %d units, got %d lines", size, len(data))
+	}
 	var unit Unit
 	units := make([]Unit, 0, size)
 	for i := 0; i < size; i++ {
@@ -0,0 +1,17 @@
+package main
+
+// Assertions checked in local builds only.
+// Built with the "release" tag the checks compile to nothing.
+
+import (
+	"fmt"
+)
+
+// invariant panics with the formatted message if the condition is false.
+// The arguments are evaluated in every build, the checks formatting values
+// are wrapped in `if debugBuild {}` like the costly debug output.
+func invariant(cond bool, format string, a ...any) {
+	if debugBuild && !cond {
+		panic(fmt.Sprintf("invariant: "+format, a...))
+	}
+}
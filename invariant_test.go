@@ -0,0 +1,23 @@
+//go:build !release
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvariant(t *testing.T) {
+	assert.NotPanics(t, func() { invariant(true, "ok") })
+	assert.PanicsWithValue(t, "invariant: bad 1", func() { invariant(false, "bad %d", 1) })
+}
+
+func TestInvariant_Geometry(t *testing.T) {
+	assert.Panics(t, func() { Point{1, 1}.Normalize(0) })
+	assert.Panics(t, func() { Line{Point{1, 1}, Point{1, 1}}.ClosestPointToLine(Point{2, 2}) })
+}
+
+func TestInvariant_InputGame(t *testing.T) {
+	assert.Panics(t, func() { InputGame(readGameTests[:2]) })
+}
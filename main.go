@@ -1,43 +1,54 @@
 package main
 
 import (
-	"bufio"
-	"math/rand"
 	"os"
 	"runtime"
+	"strconv"
 	"time"
 )
 
-var rnd *rand.Rand
+var seed int64
+var rnd *Rand
 
 func init() {
 	runtime.GOMAXPROCS(1)
-	rnd = rand.New(rand.NewSource(time.Now().UnixNano()))
+	// the seed is fixed by the BOT_SEED environment variable to reproduce a match
+	seed = time.Now().UnixNano()
+	if s, err := strconv.ParseInt(os.Getenv("BOT_SEED"), 10, 64); err == nil {
+		seed = s
+	}
+	rnd = NewRand(seed)
 	debug = true
 }
 
-func main() {
-	// example
-	scanner := bufio.NewScanner(os.Stdin)
-	scanner.Buffer(make([]byte, 1000000), 1000000)
-
-	dataGame := ReadGame(scanner)
-	asText(DataExport(dataGame))
-	game := InputGame(dataGame)
+// bot is an example of the game strategy.
+type bot struct {
+	game Game
+}
 
-	dataStep := ReadStep(scanner)
-	asText(DataExport(dataStep))
-	step := InputStep(dataStep)
+func (b *bot) Init(game Game) {
+	b.game = game
+}
 
-	// some game logic for the first step
-	u(game, step)
+// aim is an example of a tuned parameter, see cmd/tune.
+var aim = param("aim", paramAim)
 
-	for {
-		dataStep = ReadStep(scanner)
-		asText(DataExport(dataStep))
-		step = InputStep(dataStep)
+func (b *bot) Turn(step Turn) Commands {
+	// some game logic for the step
+	watches.Set("units", float64(len(b.game.Units)))
+	watches.Set("power", step.Power)
 
-		// some game logic for the next step
-		u(game, step)
+	var x, y float64
+	for _, u := range b.game.Units {
+		x += u.x / float64(len(b.game.Units))
+		y += u.y / float64(len(b.game.Units))
 	}
+
+	return Commands{MockCommand{x * step.Power * aim, y * step.Power * aim}}
+}
+
+func main() {
+	runner := NewRunner(&bot{}, os.Stdin)
+	runner.Seed = seed
+	runner.Run()
 }
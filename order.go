@@ -0,0 +1,118 @@
+package main
+
+// Order statistics in linear time.
+// The values are copied and partially ordered by quickselect,
+// the slice of the caller is never reordered. NaN values are not supported.
+
+import (
+	"math"
+)
+
+// Number is a constraint of the numeric types.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Median returns the median value of the given slice, NaN for an empty one.
+func Median[T Number](values []T) float64 {
+	return Percentile(values, 50)
+}
+
+// KthSmallest returns the k-th smallest value counting from 0, it panics if k is out of range.
+func KthSmallest[T Number](values []T, k int) T {
+	if k < 0 || k >= len(values) {
+		panic("KthSmallest: k out of range")
+	}
+	a := append([]T(nil), values...)
+	selectKth(a, k)
+	return a[k]
+}
+
+// Percentile returns the p-th percentile of the values interpolated linearly
+// between the closest ranks, NaN for an empty slice.
+func Percentile[T Number](values []T, p float64) float64 {
+	return Percentiles(values, p)[0]
+}
+
+// Percentiles returns the percentiles of the values with a single copy.
+func Percentiles[T Number](values []T, ps ...float64) []float64 {
+	result := make([]float64, len(ps))
+	if len(values) == 0 {
+		for i := range result {
+			result[i] = math.NaN()
+		}
+		return result
+	}
+
+	a := append([]T(nil), values...)
+	for i, p := range ps {
+		rank := Clamp(p, 0, 100) / 100 * float64(len(a)-1)
+		k := int(rank)
+		selectKth(a, k)
+		result[i] = float64(a[k])
+		if frac := rank - float64(k); frac > 0 {
+			// the next rank is the smallest of the greater part
+			next := a[k+1]
+			for _, v := range a[k+2:] {
+				if v < next {
+					next = v
+				}
+			}
+			result[i] += frac * (float64(next) - float64(a[k]))
+		}
+	}
+	return result
+}
+
+// IQR returns the interquartile range of the values.
+func IQR[T Number](values []T) float64 {
+	q := Percentiles(values, 25, 75)
+	return q[1] - q[0]
+}
+
+// selectKth partially orders the values so the k-th smallest is at the index k,
+// the smaller values are before it and the greater ones after.
+func selectKth[T Number](a []T, k int) {
+	lo, hi := 0, len(a)-1
+	for lo < hi {
+		// the median of three as the pivot
+		mid := lo + (hi-lo)/2
+		if a[mid] < a[lo] {
+			a[mid], a[lo] = a[lo], a[mid]
+		}
+		if a[hi] < a[lo] {
+			a[hi], a[lo] = a[lo], a[hi]
+		}
+		if a[hi] < a[mid] {
+			a[hi], a[mid] = a[mid], a[hi]
+		}
+		pivot := a[mid]
+
+		i, j := lo, hi
+		for i <= j {
+			for a[i] < pivot {
+				i++
+			}
+			for a[j] > pivot {
+				j--
+			}
+			if i <= j {
+				a[i], a[j] = a[j], a[i]
+				i++
+				j--
+			}
+		}
+
+		// the values between j and i are equal to the pivot
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return
+		}
+	}
+}
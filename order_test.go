@@ -0,0 +1,89 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedian_NotMutating(t *testing.T) {
+	values := []float64{5, 1, 4, 2, 3, 6}
+	assert.Equal(t, 3.5, Median(values))
+	assert.Equal(t, []float64{5, 1, 4, 2, 3, 6}, values)
+
+	assert.Equal(t, 3.0, Median([]int{5, 1, 3}))
+	assert.Equal(t, 7.0, Median([]uint8{7}))
+	assert.True(t, math.IsNaN(Median([]int{})))
+}
+
+func TestKthSmallest(t *testing.T) {
+	values := []int{9, 3, 7, 3, 1, 8}
+	assert.Equal(t, 1, KthSmallest(values, 0))
+	assert.Equal(t, 3, KthSmallest(values, 1))
+	assert.Equal(t, 3, KthSmallest(values, 2))
+	assert.Equal(t, 9, KthSmallest(values, 5))
+	assert.Equal(t, []int{9, 3, 7, 3, 1, 8}, values)
+
+	assert.Panics(t, func() { KthSmallest(values, 6) })
+	assert.Panics(t, func() { KthSmallest(values, -1) })
+}
+
+func TestKthSmallest_Random(t *testing.T) {
+	r := NewRand(1)
+	for n := 1; n < 50; n++ {
+		values := make([]float32, n)
+		for i := range values {
+			// few distinct values to test the duplicates
+			values[i] = float32(r.Intn(n/2 + 1))
+		}
+		sorted := append([]float32(nil), values...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		for k := 0; k < n; k++ {
+			assert.Equal(t, sorted[k], KthSmallest(values, k), "n %d k %d", n, k)
+		}
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	values := []int{15, 20, 35, 40, 50}
+	assert.Equal(t, []float64{15, 20, 29, 35, 50}, Percentiles(values, 0, 25, 40, 50, 100))
+	assert.Equal(t, 15.0, Percentile(values, -10))
+	assert.Equal(t, 50.0, Percentile(values, 110))
+	assert.Equal(t, 20.0, IQR(values))
+	assert.Equal(t, []int{15, 20, 35, 40, 50}, values)
+
+	empty := Percentiles([]float64{}, 25, 75)
+	assert.True(t, math.IsNaN(empty[0]) && math.IsNaN(empty[1]))
+}
+
+// Benchmarks
+
+var orderValues = func() []float64 {
+	r := NewRand(1)
+	values := make([]float64, 1000)
+	for i := range values {
+		values[i] = r.Float64()
+	}
+	return values
+}()
+
+func BenchmarkMedian(b *testing.B) {
+	v := 0.0
+	for i := 0; i < b.N; i++ {
+		v = Median(orderValues)
+	}
+	GlobalF = v
+}
+
+func BenchmarkMedian_Sort(b *testing.B) {
+	v := 0.0
+	for i := 0; i < b.N; i++ {
+		values := append([]float64(nil), orderValues...)
+		sort.Float64s(values)
+		v = (values[499] + values[500]) / 2
+	}
+	GlobalF = v
+}
@@ -0,0 +1,50 @@
+package main
+
+// Named parameters of the bot evaluation.
+// The values are overridden by the BOT_PARAMS environment variable
+// in the form "name=value,name=value", so local matches run by the tuner
+// can play with perturbed values without rebuilding the bot.
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+var params = ParseParams(os.Getenv("BOT_PARAMS"))
+
+// Params are the overridden parameter values by name.
+type Params map[string]float64
+
+// Get returns the overridden value of the parameter or the default value.
+func (ps Params) Get(name string, value float64) float64 {
+	if v, ok := ps[name]; ok {
+		return v
+	}
+	return value
+}
+
+// ParseParams parses the parameters in the form "name=value,name=value".
+func ParseParams(s string) Params {
+	ps := make(Params)
+	for _, pair := range strings.Split(s, ",") {
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			panic("invalid param " + pair)
+		}
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			panic(err)
+		}
+		ps[strings.TrimSpace(name)] = v
+	}
+	return ps
+}
+
+// param returns the value of the named parameter.
+func param(name string, value float64) float64 {
+	return params.Get(name, value)
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseParams(t *testing.T) {
+	assert.Equal(t, Params{}, ParseParams(""))
+	assert.Equal(t, Params{"aim": 1.5, "decay": -2}, ParseParams("aim=1.5, decay=-2"))
+	assert.Panics(t, func() { ParseParams("aim") })
+	assert.Panics(t, func() { ParseParams("aim=x") })
+}
+
+func TestParams_Get(t *testing.T) {
+	ps := Params{"aim": 1.5}
+	assert.Equal(t, 1.5, ps.Get("aim", 1))
+	assert.Equal(t, 2.0, ps.Get("decay", 2))
+}
@@ -0,0 +1,86 @@
+package main
+
+// A typed arena for the nodes of search trees.
+// The nodes live in preallocated slabs and refer to each other by integer handles,
+// so a tree of millions of nodes is a few large allocations the collector
+// does not scan when the node type has no pointers.
+
+// Handle is the index of a node in the Pool, 0 is the nil handle.
+type Handle int32
+
+// Pool hands out the nodes of the type T from the slabs of a fixed size.
+// The memory is kept when the nodes are released and reused by the next allocations.
+type Pool[T any] struct {
+	slabs [][]T
+	shift uint
+	mask  Handle
+	// next is the handle of the next node
+	next Handle
+}
+
+// NewPool returns the Pool with the slab size rounded up to a power of two.
+func NewPool[T any](slabSize int) *Pool[T] {
+	var shift uint
+	for 1<<shift < slabSize {
+		shift++
+	}
+	p := &Pool[T]{shift: shift, mask: 1<<shift - 1}
+	p.Reset()
+	return p
+}
+
+// New returns the handle of a zeroed node.
+func (p *Pool[T]) New() Handle {
+	h := p.next
+	slab := int(h >> p.shift)
+	if slab >= len(p.slabs) {
+		// the first slab holds the nil node even when it is the only one
+		for slab >= len(p.slabs) {
+			p.slabs = append(p.slabs, make([]T, p.mask+1))
+		}
+	} else {
+		var zero T
+		p.slabs[slab][h&p.mask] = zero
+	}
+	p.next++
+	return h
+}
+
+// Get returns the node of the handle, valid until the node is released.
+func (p *Pool[T]) Get(h Handle) *T {
+	invariant(h > 0 && h < p.next, "invalid handle")
+	return &p.slabs[h>>p.shift][h&p.mask]
+}
+
+// Len returns the number of the nodes in use.
+func (p *Pool[T]) Len() int {
+	return int(p.next) - 1
+}
+
+// Cap returns the number of the nodes allocated in the slabs.
+func (p *Pool[T]) Cap() int {
+	if len(p.slabs) == 0 {
+		return 0
+	}
+	// without the nil node
+	return len(p.slabs)<<p.shift - 1
+}
+
+// Mark returns the position to release the nodes allocated after it, like a subtree.
+func (p *Pool[T]) Mark() Handle {
+	return p.next
+}
+
+// Release frees the nodes allocated after the mark, their handles become invalid.
+func (p *Pool[T]) Release(mark Handle) {
+	if debugBuild {
+		invariant(mark > 0 && mark <= p.next, "invalid mark %d of %d nodes", mark, p.next)
+	}
+	p.next = mark
+}
+
+// Reset frees all the nodes, usually at the start of a turn.
+func (p *Pool[T]) Reset() {
+	// the handle 0 is reserved for nil
+	p.next = 1
+}
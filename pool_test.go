@@ -0,0 +1,142 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type poolNode struct {
+	value    float64
+	children [2]Handle
+}
+
+func TestNewPool(t *testing.T) {
+	p := NewPool[poolNode](5)
+	assert.Equal(t, uint(3), p.shift)
+	assert.Equal(t, 0, p.Len())
+	assert.Equal(t, 0, p.Cap())
+
+	p = NewPool[poolNode](1)
+	p.New()
+	assert.Equal(t, 1, p.Len())
+}
+
+func TestPool_New(t *testing.T) {
+	p := NewPool[poolNode](4)
+	handles := make([]Handle, 10)
+	for i := range handles {
+		handles[i] = p.New()
+		p.Get(handles[i]).value = float64(i)
+	}
+
+	assert.Equal(t, Handle(1), handles[0])
+	assert.Equal(t, 10, p.Len())
+	assert.Equal(t, 11, p.Cap())
+	for i, h := range handles {
+		assert.Equal(t, float64(i), p.Get(h).value)
+	}
+}
+
+func TestPool_Release(t *testing.T) {
+	p := NewPool[poolNode](4)
+	root := p.New()
+	p.Get(root).value = 1
+
+	mark := p.Mark()
+	child := p.New()
+	p.Get(child).value = 2
+	p.Get(root).children[0] = child
+	p.Release(mark)
+	assert.Equal(t, 1, p.Len())
+
+	// the released node is zeroed on reuse
+	again := p.New()
+	assert.Equal(t, child, again)
+	assert.Equal(t, poolNode{}, *p.Get(again))
+	assert.Equal(t, 1.0, p.Get(root).value)
+}
+
+func TestPool_Get_Invalid(t *testing.T) {
+	if !debugBuild {
+		t.Skip("invariants are off")
+	}
+	p := NewPool[poolNode](4)
+	h := p.New()
+	assert.Panics(t, func() { p.Get(0) })
+	assert.Panics(t, func() { p.Get(h + 1) })
+	assert.Panics(t, func() { p.Release(0) })
+}
+
+func TestPool_Reset(t *testing.T) {
+	p := NewPool[poolNode](4)
+	for i := 0; i < 10; i++ {
+		p.Get(p.New()).value = 1
+	}
+	p.Reset()
+	assert.Equal(t, 0, p.Len())
+	assert.Equal(t, 11, p.Cap())
+
+	h := p.New()
+	assert.Equal(t, Handle(1), h)
+	assert.Equal(t, poolNode{}, *p.Get(h))
+	assert.Equal(t, 11, p.Cap())
+}
+
+// Benchmarks
+
+var (
+	GlobalNode *allocNode
+)
+
+type allocNode struct {
+	value    float64
+	children [4]*allocNode
+}
+
+// buildAllocTree builds the tree of the depth with 4 children per node allocated one by one.
+func buildAllocTree(depth int) *allocNode {
+	n := &allocNode{value: float64(depth)}
+	if depth > 0 {
+		for i := range n.children {
+			n.children[i] = buildAllocTree(depth - 1)
+		}
+	}
+	return n
+}
+
+type pooledNode struct {
+	value    float64
+	children [4]Handle
+}
+
+// buildPoolTree builds the same tree in the Pool.
+func buildPoolTree(p *Pool[pooledNode], depth int) Handle {
+	h := p.New()
+	p.Get(h).value = float64(depth)
+	if depth > 0 {
+		for i := 0; i < 4; i++ {
+			child := buildPoolTree(p, depth-1)
+			p.Get(h).children[i] = child
+		}
+	}
+	return h
+}
+
+func BenchmarkTree_Alloc(b *testing.B) {
+	var n *allocNode
+	for i := 0; i < b.N; i++ {
+		n = buildAllocTree(6)
+	}
+	GlobalNode = n
+}
+
+func BenchmarkTree_Pool(b *testing.B) {
+	p := NewPool[pooledNode](1024)
+	var h Handle
+	for i := 0; i < b.N; i++ {
+		p.Reset()
+		h = buildPoolTree(p, 6)
+	}
+	GlobalI = int(h)
+}
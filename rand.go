@@ -0,0 +1,147 @@
+package main
+
+// Fast deterministic random numbers.
+// Rand is the xoshiro256** generator seeded by splitmix64, several times faster
+// than math/rand and reproducible from the seed logged into the match export.
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Rand is a seedable pseudo-random generator, not safe for concurrent use.
+type Rand struct {
+	s    [4]uint64
+	seed int64
+}
+
+// NewRand returns the Rand of the seed.
+func NewRand(seed int64) *Rand {
+	r := &Rand{seed: seed}
+	x := uint64(seed)
+	for i := range r.s {
+		x, r.s[i] = splitMix64(x)
+	}
+	return r
+}
+
+// splitMix64 returns the next state and the output of the splitmix64 generator.
+func splitMix64(x uint64) (uint64, uint64) {
+	x += 0x9e3779b97f4a7c15
+	z := x
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return x, z ^ (z >> 31)
+}
+
+// Seed returns the seed the Rand was created with.
+func (r *Rand) Seed() int64 {
+	return r.seed
+}
+
+// Uint64 returns a random 64-bit value.
+func (r *Rand) Uint64() uint64 {
+	s := &r.s
+	result := bits.RotateLeft64(s[1]*5, 7) * 9
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = bits.RotateLeft64(s[3], 45)
+	return result
+}
+
+// Intn returns a random int in [0, n), it panics if n <= 0.
+func (r *Rand) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+	// Lemire's multiply and shift with the rejection of the biased values
+	bound := uint64(n)
+	hi, lo := bits.Mul64(r.Uint64(), bound)
+	if lo < bound {
+		threshold := -bound % bound
+		for lo < threshold {
+			hi, lo = bits.Mul64(r.Uint64(), bound)
+		}
+	}
+	return int(hi)
+}
+
+// Range returns a random int in [min, max), it panics if max <= min.
+func (r *Rand) Range(min, max int) int {
+	return min + r.Intn(max-min)
+}
+
+// Float64 returns a random float in [0, 1).
+func (r *Rand) Float64() float64 {
+	return float64(r.Uint64()>>11) * 0x1p-53
+}
+
+// FloatRange returns a random float in [min, max).
+func (r *Rand) FloatRange(min, max float64) float64 {
+	return min + r.Float64()*(max-min)
+}
+
+// Bool returns a random bool.
+func (r *Rand) Bool() bool {
+	return r.Uint64()>>63 == 1
+}
+
+// Chance returns true with the probability p.
+func (r *Rand) Chance(p float64) bool {
+	return r.Float64() < p
+}
+
+// Shuffle randomizes the order of n elements by the Fisher-Yates shuffle.
+func (r *Rand) Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		swap(i, r.Intn(i+1))
+	}
+}
+
+// Perm returns a random permutation of [0, n).
+func (r *Rand) Perm(n int) []int {
+	p := make([]int, n)
+	for i := range p {
+		j := r.Intn(i + 1)
+		p[i] = p[j]
+		p[j] = i
+	}
+	return p
+}
+
+// Weighted returns a random index with the probability proportional to the weight,
+// negative weights count as zero, -1 when the total weight is zero.
+func (r *Rand) Weighted(weights []float64) int {
+	var total float64
+	for _, w := range weights {
+		total += math.Max(w, 0)
+	}
+	if total <= 0 {
+		return -1
+	}
+
+	x := r.Float64() * total
+	last := -1
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		if x < w {
+			return i
+		}
+		x -= w
+		last = i
+	}
+	// the rounding of the sum
+	return last
+}
+
+// Fork returns an independent stream seeded by this one,
+// so a subsystem draws numbers without changing the sequence of the others.
+func (r *Rand) Fork() *Rand {
+	return NewRand(int64(r.Uint64()))
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRand(t *testing.T) {
+	a, b := NewRand(42), NewRand(42)
+	assert.Equal(t, int64(42), a.Seed())
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, a.Uint64(), b.Uint64())
+	}
+	assert.NotEqual(t, NewRand(1).Uint64(), NewRand(2).Uint64())
+}
+
+func TestRand_Uint64(t *testing.T) {
+	// the reference output of xoshiro256** for the state 1, 2, 3, 4
+	r := &Rand{s: [4]uint64{1, 2, 3, 4}}
+	want := []uint64{11520, 0, 1509978240, 1215971899390074240}
+	for _, w := range want {
+		assert.Equal(t, w, r.Uint64())
+	}
+}
+
+func TestRand_Intn(t *testing.T) {
+	r := NewRand(1)
+	counts := make([]int, 6)
+	for i := 0; i < 60000; i++ {
+		counts[r.Intn(6)]++
+	}
+	for _, c := range counts {
+		assert.InDelta(t, 10000, c, 500)
+	}
+	assert.Equal(t, 0, r.Intn(1))
+	assert.Panics(t, func() { r.Intn(0) })
+}
+
+func TestRand_Range(t *testing.T) {
+	r := NewRand(1)
+	seen := make(map[int]bool)
+	for i := 0; i < 1000; i++ {
+		v := r.Range(-2, 3)
+		assert.True(t, v >= -2 && v < 3)
+		seen[v] = true
+	}
+	assert.Len(t, seen, 5)
+}
+
+func TestRand_Float64(t *testing.T) {
+	r := NewRand(1)
+	var sum float64
+	for i := 0; i < 10000; i++ {
+		v := r.Float64()
+		assert.True(t, v >= 0 && v < 1)
+		sum += v
+	}
+	assert.InDelta(t, 0.5, sum/10000, 0.01)
+
+	for i := 0; i < 100; i++ {
+		v := r.FloatRange(-1, 1)
+		assert.True(t, v >= -1 && v < 1)
+	}
+}
+
+func TestRand_Chance(t *testing.T) {
+	r := NewRand(1)
+	var hits, heads int
+	for i := 0; i < 10000; i++ {
+		if r.Chance(0.25) {
+			hits++
+		}
+		if r.Bool() {
+			heads++
+		}
+	}
+	assert.InDelta(t, 2500, hits, 150)
+	assert.InDelta(t, 5000, heads, 200)
+	assert.False(t, r.Chance(0))
+}
+
+func TestRand_Shuffle(t *testing.T) {
+	r := NewRand(1)
+	values := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	r.Shuffle(len(values), func(i, j int) {
+		values[i], values[j] = values[j], values[i]
+	})
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, values)
+	assert.NotEqual(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, values)
+
+	assert.ElementsMatch(t, []int{0, 1, 2, 3, 4}, r.Perm(5))
+	assert.Empty(t, r.Perm(0))
+}
+
+func TestRand_Weighted(t *testing.T) {
+	r := NewRand(1)
+	counts := make([]int, 4)
+	for i := 0; i < 10000; i++ {
+		counts[r.Weighted([]float64{1, 0, 3, -1})]++
+	}
+	assert.InDelta(t, 2500, counts[0], 200)
+	assert.Equal(t, 0, counts[1])
+	assert.InDelta(t, 7500, counts[2], 200)
+	assert.Equal(t, 0, counts[3])
+
+	assert.Equal(t, -1, r.Weighted(nil))
+	assert.Equal(t, -1, r.Weighted([]float64{0, -1}))
+	assert.Equal(t, 1, r.Weighted([]float64{0, math.SmallestNonzeroFloat64}))
+}
+
+func TestRand_Fork(t *testing.T) {
+	a, b := NewRand(1), NewRand(1)
+	fa, fb := a.Fork(), b.Fork()
+	assert.Equal(t, fa.Uint64(), fb.Uint64())
+
+	// drawing from a fork does not change the parent
+	for i := 0; i < 10; i++ {
+		fa.Uint64()
+	}
+	assert.Equal(t, a.Uint64(), b.Uint64())
+	assert.NotEqual(t, a.Uint64(), fa.Uint64())
+}
+
+// Benchmarks
+
+func BenchmarkRand_Intn(b *testing.B) {
+	r := NewRand(1)
+	v := 0
+	for i := 0; i < b.N; i++ {
+		v += r.Intn(100)
+	}
+	GlobalI = v
+}
+
+func BenchmarkMathRand_Intn(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	v := 0
+	for i := 0; i < b.N; i++ {
+		v += r.Intn(100)
+	}
+	GlobalI = v
+}
+
+func BenchmarkRand_Float64(b *testing.B) {
+	r := NewRand(1)
+	v := 0.0
+	for i := 0; i < b.N; i++ {
+		v += r.Float64()
+	}
+	GlobalF = v
+}
@@ -0,0 +1,229 @@
+package main
+
+// Running a Bot over the game protocol.
+// The Runner owns the read/parse/export/execute loop, timing and crash handling,
+// so a bot is a plain type that can be run from stdin, a replay or a test.
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Bot is a game strategy.
+type Bot interface {
+	// Init is called once with the initial game data.
+	Init(game Game)
+	// Turn returns the commands for the turn.
+	Turn(step Turn) Commands
+}
+
+// Ponderer is a Bot searching on while the opponent plays.
+type Ponderer interface {
+	// Ponder continues the search of the last turn until stop returns true
+	// and keeps the state for the next Turn. The stop is cheap to call in tight loops,
+	// the next turn starts only after Ponder returns.
+	Ponder(stop func() bool)
+}
+
+// Runner runs the Bot turn by turn.
+type Runner struct {
+	Bot Bot
+	// Fallback is emitted when the Bot panics.
+	Fallback Commands
+	// Seed is the random seed logged with the game input and reported on crash.
+	Seed int64
+	// Budget is the time limit of the turns tracked by the Timer.
+	Budget TimeBudget
+	// Ponder runs a Ponderer Bot while waiting for the next turn input.
+	Ponder bool
+	// GC controls the garbage collector during the turns.
+	GC GCPolicy
+	// Timer is reset for every turn, the package timer checked by the bots by default.
+	Timer *Timer
+	// Watches are exported and moved to the next turn after every turn,
+	// the package watches set by the bots by default.
+	Watches *Watches
+	// Output receives the commands, the package command output by default.
+	Output io.Writer
+	// CrashOutput receives the crash dumps, the package crash output by default.
+	CrashOutput io.Writer
+
+	input   *arrivalReader
+	scanner *bufio.Scanner
+	// arrival is the time the game input has arrived, the first turn starts with it.
+	arrival time.Time
+	dump    CrashDump
+	turn    int
+	// times are the turn times in milliseconds
+	times RunningStats
+}
+
+// Run reads the game and plays the turns until the input is over.
+func (r *Runner) Run() {
+	r.Init()
+	for r.Step() {
+	}
+	asText("turn times ms", r.times)
+}
+
+// Init reads the initial game data and initializes the Bot.
+func (r *Runner) Init() {
+	r.input.Mark()
+	data := ReadGame(r.scanner)
+	r.arrival = r.input.Arrival()
+	r.Timer.Reset(r.arrival, r.Budget.Limit(0))
+	asText("seed", r.Seed)
+	if isDebug() {
+		asText(DataExport(data))
+	}
+
+	r.dump.Seed = r.Seed
+	r.dump.Game = data
+	r.dump.Watches = r.Watches
+	restore := r.GC.Begin()
+	r.safeTurn(nil, func() Commands {
+		r.Bot.Init(InputGame(data))
+		return nil
+	})
+	restore()
+}
+
+// Step reads the turn data, executes the Bot commands and returns false when the input is over.
+func (r *Runner) Step() bool {
+	r.input.Mark()
+	stop := r.ponder()
+	data := ReadStep(r.scanner)
+	stop()
+	// the input is over
+	if data[0] == "" {
+		return false
+	}
+	start := r.input.Arrival()
+	if r.turn == 0 {
+		start = r.arrival
+	}
+	r.Timer.Reset(start, r.Budget.Limit(r.turn))
+
+	// the memory statistics stop the world, they are read for the debug output only
+	var meter gcMeter
+	if isDebug() {
+		asText(DataExport(data))
+		meter.Start()
+	}
+	restore := r.GC.Begin()
+
+	r.dump.Track(r.turn, data)
+	r.execute(r.safeTurn(r.Fallback, func() Commands {
+		return r.Bot.Turn(InputStep(data))
+	}))
+	restore()
+	elapsed := r.Timer.Elapsed()
+	r.times.Add(float64(elapsed) / float64(time.Millisecond))
+	asText("turn", r.turn, elapsed, "remaining", r.Timer.Remaining())
+
+	if isDebug() {
+		stats := meter.Stop()
+		asText("gc", stats, "collect", r.GC.End())
+		asText(r.Watches.Sparklines())
+		if data := r.Watches.ExportTurn(); len(data) > 0 {
+			asText("watch", DataExport(data))
+		}
+	} else {
+		r.GC.End()
+	}
+
+	r.Watches.NextTurn()
+	r.turn++
+
+	return true
+}
+
+// ponder runs the Ponderer until the input arrives and returns the function waiting for it to stop.
+// An extra processor is added while pondering, so reading the input is never delayed by the search.
+func (r *Runner) ponder() func() {
+	p, ok := r.Bot.(Ponderer)
+	if !r.Ponder || !ok || r.turn == 0 {
+		return func() {}
+	}
+
+	var stopped int32
+	r.input.OnArrival = func() {
+		atomic.StoreInt32(&stopped, 1)
+	}
+	procs := runtime.GOMAXPROCS(0)
+	runtime.GOMAXPROCS(procs + 1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.safeTurn(nil, func() Commands {
+			p.Ponder(func() bool {
+				return atomic.LoadInt32(&stopped) == 1
+			})
+			return nil
+		})
+	}()
+
+	return func() {
+		atomic.StoreInt32(&stopped, 1)
+		<-done
+		r.input.OnArrival = nil
+		runtime.GOMAXPROCS(procs)
+	}
+}
+
+// safeTurn runs the turn function recovering from a panic with the dump to the CrashOutput.
+func (r *Runner) safeTurn(fallback Commands, turn func() Commands) Commands {
+	return safeTurn(r.CrashOutput, &r.dump, fallback, turn)
+}
+
+// execute writes the commands to the Output.
+func (r *Runner) execute(commands Commands) {
+	for _, command := range commands {
+		fmt.Fprintln(r.Output, command)
+	}
+}
+
+// Turn returns the number of played turns.
+func (r *Runner) Turn() int {
+	return r.turn
+}
+
+// TurnTimes returns the statistics of the turn times in milliseconds.
+func (r *Runner) TurnTimes() RunningStats {
+	return r.times
+}
+
+func NewRunner(bot Bot, input io.Reader) *Runner {
+	arrival := &arrivalReader{r: input}
+	scanner := bufio.NewScanner(arrival)
+	scanner.Buffer(make([]byte, 1000000), 1000000)
+
+	return &Runner{
+		Bot:         bot,
+		Fallback:    fallbackCommands,
+		Budget:      DefaultTimeBudget(),
+		GC:          DefaultGCPolicy(),
+		Timer:       timer,
+		Watches:     watches,
+		Output:      commandOutput,
+		CrashOutput: crashOutput,
+		input:       arrival,
+		scanner:     scanner,
+	}
+}
+
+// NewReplayReader returns the input stream of a match recorded by DataExport,
+// the first blob is the game data followed by the turns.
+func NewReplayReader(blobs ...string) io.Reader {
+	var lines []string
+	for _, blob := range blobs {
+		lines = append(lines, DataImport(blob)...)
+	}
+	return strings.NewReader(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBot struct {
+	game  Game
+	steps []Turn
+}
+
+func (b *mockBot) Init(game Game) {
+	b.game = game
+}
+
+func (b *mockBot) Turn(step Turn) Commands {
+	b.steps = append(b.steps, step)
+	if step.L == "panic" {
+		panic("mock bot")
+	}
+	return Commands{MockCommand{step.Power, float64(len(b.steps))}}
+}
+
+var runnerTests = append(append([]string{}, readGameTests...), "1 R L", "2 L R")
+
+// setOutput replaces the output for the test and restores it on cleanup.
+func setOutput(t *testing.T, output *io.Writer, w io.Writer) {
+	t.Helper()
+	prev := *output
+	*output = w
+	t.Cleanup(func() {
+		*output = prev
+	})
+}
+
+// newRunnerTests returns the Runner of the bot with its own timer and watches,
+// discarding the commands, the crash dumps and the debug output.
+func newRunnerTests(t *testing.T, bot Bot, input io.Reader) *Runner {
+	t.Helper()
+	setOutput(t, &debugOutput, io.Discard)
+
+	runner := NewRunner(bot, input)
+	runner.Timer = NewTimer()
+	runner.Watches = NewWatches()
+	runner.Output = io.Discard
+	runner.CrashOutput = io.Discard
+	return runner
+}
+
+func TestRunner_Run(t *testing.T) {
+	out := &bytes.Buffer{}
+	bot := &mockBot{}
+	runner := newRunnerTests(t, bot, strings.NewReader(strings.Join(runnerTests, "\n")))
+	runner.Output = out
+	runner.Run()
+
+	assert.Equal(t, InputGame(readGameTests), bot.game)
+	assert.Equal(t, []Turn{{1, "R", "L"}, {2, "L", "R"}}, bot.steps)
+	assert.Equal(t, 2, runner.Turn())
+	assert.Equal(t, 2, runner.TurnTimes().N)
+	assert.Equal(t, "1 1\n2 2\n", out.String())
+}
+
+func TestRunner_Step_Panic(t *testing.T) {
+	out := &bytes.Buffer{}
+	crash := &bytes.Buffer{}
+	input := append(append([]string{}, readGameTests...), "1 panic L", "2 L R")
+	runner := newRunnerTests(t, &mockBot{}, strings.NewReader(strings.Join(input, "\n")))
+	runner.Output = out
+	runner.CrashOutput = crash
+	runner.Fallback = Commands{MockCommand{9, 9}}
+	runner.Seed = 42
+	runner.Run()
+
+	assert.Equal(t, "9 9\n2 2\n", out.String())
+	assert.Contains(t, crash.String(), "panic: mock bot\nturn: 0\nseed: 42\n")
+}
+
+func TestNewReplayReader(t *testing.T) {
+	out := &bytes.Buffer{}
+	replay := NewReplayReader(DataExport(readGameTests), DataExport(readStepTests))
+	bot := &mockBot{}
+	runner := newRunnerTests(t, bot, replay)
+	runner.Output = out
+	runner.Run()
+
+	assert.Equal(t, []Turn{{1, "R", "L"}}, bot.steps)
+	assert.Equal(t, "1 1\n", out.String())
+}
+
+// ponderBot counts the search iterations and records the order of the calls.
+type ponderBot struct {
+	timer      *Timer
+	iterations int
+	calls      []string
+	starts     []time.Time
+}
+
+func (b *ponderBot) Init(game Game) {}
+
+func (b *ponderBot) Turn(step Turn) Commands {
+	b.calls = append(b.calls, "turn")
+	b.starts = append(b.starts, b.timer.Start())
+	return nil
+}
+
+func (b *ponderBot) Ponder(stop func() bool) {
+	b.calls = append(b.calls, "ponder")
+	for !stop() {
+		b.iterations++
+	}
+}
+
+func TestRunner_Ponder(t *testing.T) {
+	pr, pw := io.Pipe()
+	var sent time.Time
+	bot := &ponderBot{}
+	runner := newRunnerTests(t, bot, pr)
+	runner.Ponder = true
+	bot.timer = runner.Timer
+
+	go func() {
+		io.WriteString(pw, strings.Join(readGameTests, "\n")+"\n1 R L\n")
+		time.Sleep(20 * time.Millisecond)
+		sent = time.Now()
+		io.WriteString(pw, "2 L R\n")
+		pw.Close()
+	}()
+	runner.Run()
+
+	assert.Equal(t, []string{"turn", "ponder", "turn", "ponder"}, bot.calls)
+	assert.Greater(t, bot.iterations, 0)
+	// the input is read while the search is running
+	assert.Less(t, bot.starts[1].Sub(sent), 5*time.Millisecond)
+	assert.Equal(t, 1, runtime.GOMAXPROCS(0))
+}
+
+func TestRunner_Ponder_Off(t *testing.T) {
+	bot := &ponderBot{}
+	runner := newRunnerTests(t, bot, strings.NewReader(strings.Join(runnerTests, "\n")))
+	bot.timer = runner.Timer
+	runner.Run()
+	assert.Equal(t, []string{"turn", "turn"}, bot.calls)
+}
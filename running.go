@@ -0,0 +1,176 @@
+package main
+
+// Streaming statistics of the samples without storing them.
+// The accumulators do not allocate on Add and are plain values
+// with exported fields, so they are printed with asJson.
+// RunningStats are merged across searches, EMA and Window depend on the order
+// of the samples, so the streams of separate searches have no meaningful merge.
+
+import (
+	"fmt"
+	"math"
+)
+
+// RunningStats accumulates the count, mean, variance, min and max of the samples by Welford's method.
+type RunningStats struct {
+	N    int
+	Mean float64
+	// M2 is the sum of the squared differences from the mean.
+	M2  float64
+	Min float64
+	Max float64
+}
+
+// Add adds the sample.
+func (s *RunningStats) Add(x float64) {
+	s.N++
+	if s.N == 1 {
+		s.Mean, s.Min, s.Max = x, x, x
+		return
+	}
+	delta := x - s.Mean
+	s.Mean += delta / float64(s.N)
+	s.M2 += delta * (x - s.Mean)
+	s.Min = math.Min(s.Min, x)
+	s.Max = math.Max(s.Max, x)
+}
+
+// Merge adds the samples of the other stats.
+func (s *RunningStats) Merge(o RunningStats) {
+	if o.N == 0 {
+		return
+	}
+	if s.N == 0 {
+		*s = o
+		return
+	}
+	n := s.N + o.N
+	delta := o.Mean - s.Mean
+	s.M2 += o.M2 + delta*delta*float64(s.N)*float64(o.N)/float64(n)
+	s.Mean += delta * float64(o.N) / float64(n)
+	s.Min = math.Min(s.Min, o.Min)
+	s.Max = math.Max(s.Max, o.Max)
+	s.N = n
+}
+
+// Variance returns the population variance of the samples.
+func (s RunningStats) Variance() float64 {
+	if s.N == 0 {
+		return 0
+	}
+	return s.M2 / float64(s.N)
+}
+
+// SampleVariance returns the unbiased variance estimate of the samples.
+func (s RunningStats) SampleVariance() float64 {
+	if s.N < 2 {
+		return 0
+	}
+	return s.M2 / float64(s.N-1)
+}
+
+// StdDev returns the population standard deviation of the samples.
+func (s RunningStats) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+func (s RunningStats) String() string {
+	return fmt.Sprintf("n %d mean %.4g sd %.4g min %.4g max %.4g", s.N, s.Mean, s.StdDev(), s.Min, s.Max)
+}
+
+// EMA is the exponential moving average, the first sample is the initial value.
+type EMA struct {
+	// Alpha is the weight of a new sample.
+	Alpha float64
+	Value float64
+	N     int
+}
+
+// NewEMA returns the EMA with the weight of the span of n samples, alpha = 2 / (n + 1),
+// the span is at least 1 sample.
+func NewEMA(n int) EMA {
+	if n < 1 {
+		n = 1
+	}
+	return EMA{Alpha: 2 / float64(n+1)}
+}
+
+// Add adds the sample.
+func (e *EMA) Add(x float64) {
+	e.N++
+	if e.N == 1 {
+		e.Value = x
+		return
+	}
+	e.Value += e.Alpha * (x - e.Value)
+}
+
+func (e EMA) String() string {
+	return fmt.Sprintf("ema %.4g", e.Value)
+}
+
+// Window is the moving average of the last samples.
+// A copy shares the Values with the original, use Clone for an independent one.
+type Window struct {
+	Values []float64
+	Sum    float64
+	// N is the number of the samples added, the last len(Values) are kept.
+	N int
+}
+
+// NewWindow returns the Window of the size, the size is at least 1.
+func NewWindow(size int) Window {
+	if size < 1 {
+		size = 1
+	}
+	return Window{Values: make([]float64, size)}
+}
+
+// Clone returns the copy of the Window with its own Values.
+func (w Window) Clone() Window {
+	w.Values = append([]float64(nil), w.Values...)
+	return w
+}
+
+// Add adds the sample replacing the oldest one.
+func (w *Window) Add(x float64) {
+	i := w.N % len(w.Values)
+	w.Sum += x - w.Values[i]
+	w.Values[i] = x
+	w.N++
+	// the sum is recounted once per round against the rounding drift
+	if i == len(w.Values)-1 {
+		w.Sum = 0
+		for _, v := range w.Values {
+			w.Sum += v
+		}
+	}
+}
+
+// Len returns the number of the samples in the Window.
+func (w Window) Len() int {
+	if w.N < len(w.Values) {
+		return w.N
+	}
+	return len(w.Values)
+}
+
+// Mean returns the average of the samples in the Window, 0 when it is empty.
+func (w Window) Mean() float64 {
+	if w.N == 0 {
+		return 0
+	}
+	return w.Sum / float64(w.Len())
+}
+
+// Last returns the last sample, NaN when the Window is empty.
+func (w Window) Last() float64 {
+	if w.N == 0 {
+		return math.NaN()
+	}
+	return w.Values[(w.N-1)%len(w.Values)]
+}
+
+func (w Window) String() string {
+	return fmt.Sprintf("window %d mean %.4g", w.Len(), w.Mean())
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var runningValues = []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+func TestRunningStats(t *testing.T) {
+	var s RunningStats
+	assert.Equal(t, 0.0, s.Variance())
+	for _, v := range runningValues {
+		s.Add(v)
+	}
+
+	assert.Equal(t, 8, s.N)
+	assert.Equal(t, 5.0, s.Mean)
+	assert.Equal(t, 4.0, s.Variance())
+	assert.InDelta(t, 32.0/7, s.SampleVariance(), 1e-12)
+	assert.Equal(t, 2.0, s.StdDev())
+	assert.Equal(t, 2.0, s.Min)
+	assert.Equal(t, 9.0, s.Max)
+	assert.Equal(t, "n 8 mean 5 sd 2 min 2 max 9", s.String())
+}
+
+func TestRunningStats_Merge(t *testing.T) {
+	var a, b, all RunningStats
+	for i, v := range runningValues {
+		if i < 3 {
+			a.Add(v)
+		} else {
+			b.Add(v)
+		}
+		all.Add(v)
+	}
+	a.Merge(b)
+	assert.Equal(t, all.N, a.N)
+	assert.InDelta(t, all.Mean, a.Mean, 1e-12)
+	assert.InDelta(t, all.M2, a.M2, 1e-12)
+	assert.Equal(t, all.Min, a.Min)
+	assert.Equal(t, all.Max, a.Max)
+
+	var empty RunningStats
+	empty.Merge(all)
+	assert.Equal(t, all, empty)
+	empty.Merge(RunningStats{})
+	assert.Equal(t, all, empty)
+}
+
+func TestRunningStats_Json(t *testing.T) {
+	var s RunningStats
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.Equal(t, `{"N":0,"Mean":0,"M2":0,"Min":0,"Max":0}`, string(data))
+
+	s.Add(1)
+	s.Add(3)
+	data, err = json.Marshal(s)
+	require.NoError(t, err)
+	assert.Equal(t, `{"N":2,"Mean":2,"M2":2,"Min":1,"Max":3}`, string(data))
+
+	var got RunningStats
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, s, got)
+}
+
+func TestEMA(t *testing.T) {
+	e := NewEMA(3)
+	assert.Equal(t, 0.5, e.Alpha)
+	e.Add(10)
+	assert.Equal(t, 10.0, e.Value)
+	e.Add(20)
+	assert.Equal(t, 15.0, e.Value)
+	e.Add(15)
+	assert.Equal(t, 15.0, e.Value)
+	assert.Equal(t, "ema 15", e.String())
+}
+
+func TestWindow(t *testing.T) {
+	w := NewWindow(3)
+	assert.Equal(t, 0.0, w.Mean())
+	assert.True(t, math.IsNaN(w.Last()))
+
+	w.Add(1)
+	w.Add(2)
+	assert.Equal(t, 2, w.Len())
+	assert.Equal(t, 1.5, w.Mean())
+
+	w.Add(3)
+	w.Add(10)
+	assert.Equal(t, 3, w.Len())
+	assert.Equal(t, 5.0, w.Mean())
+	assert.Equal(t, 10.0, w.Last())
+	assert.Equal(t, "window 3 mean 5", w.String())
+}
+
+func TestWindow_Size(t *testing.T) {
+	w := NewWindow(0)
+	w.Add(1)
+	w.Add(2)
+	assert.Equal(t, 1, w.Len())
+	assert.Equal(t, 2.0, w.Mean())
+
+	e := NewEMA(0)
+	e.Add(1)
+	e.Add(2)
+	assert.Equal(t, 2.0, e.Value)
+}
+
+func TestWindow_Clone(t *testing.T) {
+	w := NewWindow(2)
+	w.Add(1)
+	c := w.Clone()
+	c.Add(5)
+	c.Add(7)
+	assert.Equal(t, 1.0, w.Last())
+	assert.Equal(t, 1.0, w.Mean())
+	assert.Equal(t, 6.0, c.Mean())
+}
+
+func TestRunning_Allocs(t *testing.T) {
+	var s RunningStats
+	e := NewEMA(10)
+	w := NewWindow(10)
+	allocs := testing.AllocsPerRun(100, func() {
+		s.Add(1)
+		e.Add(1)
+		w.Add(1)
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+// Benchmarks
+
+func BenchmarkRunningStats_Add(b *testing.B) {
+	var s RunningStats
+	for i := 0; i < b.N; i++ {
+		s.Add(float64(i & 1023))
+	}
+	GlobalF = s.Mean
+}
+
+func BenchmarkWindow_Add(b *testing.B) {
+	w := NewWindow(64)
+	for i := 0; i < b.N; i++ {
+		w.Add(float64(i & 1023))
+	}
+	GlobalF = w.Mean()
+}
@@ -0,0 +1,115 @@
+package main
+
+// Shaping functions of the evaluation.
+// They map the raw features like distances and counts to the comparable scores,
+// the decay curves are normalized to 1 at 0 and fall towards 0.
+
+import (
+	"math"
+	"sort"
+)
+
+// Clamp returns the value limited to the range [lo, hi].
+func Clamp[T Number](x, lo, hi T) T {
+	if x < lo {
+		return lo
+	}
+	if x > hi {
+		return hi
+	}
+	return x
+}
+
+// Lerp returns the linear interpolation between a and b, t of 0 is a and 1 is b.
+func Lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// InvLerp returns the position t of x between a and b, the inverse of Lerp, 0 when a equals b.
+func InvLerp(a, b, x float64) float64 {
+	if a == b {
+		return 0
+	}
+	return (x - a) / (b - a)
+}
+
+// Remap maps x from the range [inLo, inHi] to [outLo, outHi] linearly without clamping.
+func Remap(x, inLo, inHi, outLo, outHi float64) float64 {
+	return Lerp(outLo, outHi, InvLerp(inLo, inHi, x))
+}
+
+// RemapClamped is the Remap limited to the output range.
+func RemapClamped(x, inLo, inHi, outLo, outHi float64) float64 {
+	return Lerp(outLo, outHi, Clamp(InvLerp(inLo, inHi, x), 0, 1))
+}
+
+// Smoothstep returns 0 below edge0, 1 above edge1 and the smooth Hermite curve between them.
+func Smoothstep(edge0, edge1, x float64) float64 {
+	t := Clamp(InvLerp(edge0, edge1, x), 0, 1)
+	return t * t * (3 - 2*t)
+}
+
+// Smootherstep is the Smoothstep with the zero second derivative at the edges.
+func Smootherstep(edge0, edge1, x float64) float64 {
+	t := Clamp(InvLerp(edge0, edge1, x), 0, 1)
+	return t * t * t * (t*(6*t-15) + 10)
+}
+
+// Sigmoid returns the logistic function 1 / (1 + e^-x) in the range (0, 1).
+func Sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
+
+// Logistic returns the Sigmoid of x centered at mid, 0.5 at mid,
+// the steepness is the slope scale, negative for the falling curve.
+func Logistic(x, mid, steepness float64) float64 {
+	return Sigmoid(steepness * (x - mid))
+}
+
+// ExpDecay returns the exponential decay of x ≥ 0 halving every halfLife.
+func ExpDecay(x, halfLife float64) float64 {
+	return math.Exp2(-x / halfLife)
+}
+
+// LinearDecay returns the linear decay of x ≥ 0 reaching 0 at zero.
+func LinearDecay(x, zero float64) float64 {
+	return Clamp(1-x/zero, 0, 1)
+}
+
+// InverseDecay returns the hyperbolic decay 1 / (1 + x/scale) of x ≥ 0, 0.5 at scale.
+// It falls slower than ExpDecay and never reaches 0.
+func InverseDecay(x, scale float64) float64 {
+	return 1 / (1 + x/scale)
+}
+
+// Curve is the piecewise-linear function through the points ordered by X.
+type Curve []Point
+
+// NewCurve returns the Curve through the points.
+func NewCurve(points ...Point) Curve {
+	c := append(Curve(nil), points...)
+	sort.SliceStable(c, func(i, j int) bool {
+		return c[i].X < c[j].X
+	})
+	return c
+}
+
+// At returns the value interpolated between the points,
+// the ends are extended flat, 0 for an empty Curve.
+func (c Curve) At(x float64) float64 {
+	if len(c) == 0 {
+		return 0
+	}
+	// the first point to the right of x
+	i := sort.Search(len(c), func(i int) bool {
+		return c[i].X > x
+	})
+	if i == 0 {
+		return c[0].Y
+	}
+	if i == len(c) {
+		return c[len(c)-1].Y
+	}
+	a, b := c[i-1], c[i]
+	return Lerp(a.Y, b.Y, InvLerp(a.X, b.X, x))
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClamp(t *testing.T) {
+	assert.Equal(t, 5, Clamp(5, 0, 10))
+	assert.Equal(t, 0, Clamp(-5, 0, 10))
+	assert.Equal(t, 10, Clamp(15, 0, 10))
+	assert.Equal(t, 0.5, Clamp(0.5, 0.0, 1.0))
+	assert.Equal(t, 1.0, Clamp(math.Inf(1), 0, 1))
+}
+
+func TestLerp(t *testing.T) {
+	assert.Equal(t, 10.0, Lerp(10, 20, 0))
+	assert.Equal(t, 20.0, Lerp(10, 20, 1))
+	assert.Equal(t, 15.0, Lerp(10, 20, 0.5))
+	assert.Equal(t, 30.0, Lerp(10, 20, 2))
+}
+
+func TestInvLerp(t *testing.T) {
+	assert.Equal(t, 0.0, InvLerp(10, 20, 10))
+	assert.Equal(t, 1.0, InvLerp(10, 20, 20))
+	assert.Equal(t, 0.25, InvLerp(10, 20, 12.5))
+	assert.Equal(t, 0.75, InvLerp(20, 10, 12.5))
+	assert.Equal(t, 0.0, InvLerp(10, 10, 12.5))
+
+	for _, x := range []float64{-3, 0, 0.3, 7} {
+		assert.InDelta(t, x, InvLerp(-4, 9, Lerp(-4, 9, x)), 1e-12)
+	}
+}
+
+func TestRemap(t *testing.T) {
+	tests := []struct {
+		name string
+		x    float64
+		want float64
+		clmp float64
+	}{
+		{
+			name: `low`,
+			x:    0,
+			want: 100,
+			clmp: 100,
+		},
+		{
+			name: `middle`,
+			x:    5,
+			want: 50,
+			clmp: 50,
+		},
+		{
+			name: `high`,
+			x:    10,
+			want: 0,
+			clmp: 0,
+		},
+		{
+			name: `outside`,
+			x:    20,
+			want: -100,
+			clmp: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, Remap(tc.x, 0, 10, 100, 0), 1e-12)
+			assert.InDelta(t, tc.clmp, RemapClamped(tc.x, 0, 10, 100, 0), 1e-12)
+		})
+	}
+}
+
+func TestSmoothstep(t *testing.T) {
+	for _, f := range []func(float64, float64, float64) float64{Smoothstep, Smootherstep} {
+		assert.Equal(t, 0.0, f(2, 4, 1))
+		assert.Equal(t, 0.0, f(2, 4, 2))
+		assert.Equal(t, 0.5, f(2, 4, 3))
+		assert.Equal(t, 1.0, f(2, 4, 4))
+		assert.Equal(t, 1.0, f(2, 4, 5))
+		assert.Less(t, f(2, 4, 2.5), 0.25)
+		assert.Greater(t, f(2, 4, 3.5), 0.75)
+	}
+	assert.InDelta(t, 0.15625, Smoothstep(0, 1, 0.25), 1e-12)
+	assert.InDelta(t, 0.103515625, Smootherstep(0, 1, 0.25), 1e-12)
+}
+
+func TestSigmoid(t *testing.T) {
+	assert.Equal(t, 0.5, Sigmoid(0))
+	assert.InDelta(t, 1-Sigmoid(2), Sigmoid(-2), 1e-12)
+	assert.InDelta(t, 0.8807970779778823, Sigmoid(2), 1e-12)
+	assert.Equal(t, 1.0, Sigmoid(1000))
+	assert.Equal(t, 0.0, Sigmoid(-1000))
+}
+
+func TestLogistic(t *testing.T) {
+	assert.Equal(t, 0.5, Logistic(10, 10, 3))
+	assert.InDelta(t, Sigmoid(2), Logistic(11, 10, 2), 1e-12)
+	// falling
+	assert.Less(t, Logistic(11, 10, -2), 0.5)
+}
+
+func TestDecay(t *testing.T) {
+	tests := []struct {
+		name  string
+		decay func(x, scale float64) float64
+		half  float64
+	}{
+		{
+			name:  `exp`,
+			decay: ExpDecay,
+			half:  10,
+		},
+		{
+			name:  `linear`,
+			decay: LinearDecay,
+			half:  5,
+		},
+		{
+			name:  `inverse`,
+			decay: InverseDecay,
+			half:  10,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, 1.0, tc.decay(0, 10))
+			assert.InDelta(t, 0.5, tc.decay(tc.half, 10), 1e-12)
+
+			prev := 1.0
+			for x := 1.0; x < 100; x++ {
+				v := tc.decay(x, 10)
+				assert.LessOrEqual(t, v, prev)
+				assert.GreaterOrEqual(t, v, 0.0)
+				prev = v
+			}
+		})
+	}
+
+	assert.Equal(t, 0.0, LinearDecay(20, 10))
+	assert.InDelta(t, 0.25, ExpDecay(20, 10), 1e-12)
+}
+
+func TestCurve_At(t *testing.T) {
+	c := NewCurve(Point{10, 0}, Point{0, 1}, Point{5, 0.5}, Point{20, 0})
+
+	tests := []struct {
+		name string
+		x    float64
+		want float64
+	}{
+		{
+			name: `before`,
+			x:    -5,
+			want: 1,
+		},
+		{
+			name: `first`,
+			x:    0,
+			want: 1,
+		},
+		{
+			name: `segment`,
+			x:    2.5,
+			want: 0.75,
+		},
+		{
+			name: `point`,
+			x:    5,
+			want: 0.5,
+		},
+		{
+			name: `steep`,
+			x:    7.5,
+			want: 0.25,
+		},
+		{
+			name: `flat`,
+			x:    15,
+			want: 0,
+		},
+		{
+			name: `after`,
+			x:    100,
+			want: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.InDelta(t, tc.want, c.At(tc.x), 1e-12)
+		})
+	}
+}
+
+func TestCurve_Step(t *testing.T) {
+	// two points at the same X make a step
+	c := NewCurve(Point{0, 0}, Point{1, 0}, Point{1, 1}, Point{2, 1})
+	assert.Equal(t, 0.0, c.At(0.99))
+	assert.Equal(t, 1.0, c.At(1))
+	assert.Equal(t, 1.0, c.At(1.5))
+
+	assert.Equal(t, 0.0, NewCurve().At(1))
+	assert.Equal(t, 3.0, NewCurve(Point{1, 3}).At(0))
+}
+
+// Benchmarks
+
+func BenchmarkCurve_At(b *testing.B) {
+	c := NewCurve(Point{0, 1}, Point{5, 0.5}, Point{10, 0.2}, Point{20, 0.1}, Point{50, 0})
+	v := 0.0
+	for i := 0; i < b.N; i++ {
+		v = c.At(float64(i % 60))
+	}
+	GlobalF = v
+}
@@ -0,0 +1,249 @@
+package main
+
+// Root finding and 1-D optimization.
+// The tolerance is the absolute delta of IsFloatsEqual: the iterative methods
+// stop when the bracket of the root or the minimum is within the tolerance,
+// when it can not shrink at the float resolution or after solveIterations.
+
+import (
+	"math"
+)
+
+const (
+	// solveIterations limits the iterations of the methods, a time-limited bot never hangs
+	// on a tolerance below the float resolution of the arguments.
+	solveIterations = 100
+	// cubicEpsilon is the relative delta of the discriminant of the cubic equation
+	// considered 0, the rounding would split a double root.
+	cubicEpsilon = 1e-12
+)
+
+// SolveQuadratic returns the real roots of ax² + bx + c = 0 in ascending order and their number.
+// A double root is returned once, the linear equation is solved when a is 0.
+func SolveQuadratic(a, b, c float64) ([2]float64, int) {
+	var roots [2]float64
+	if a == 0 {
+		if b == 0 {
+			return roots, 0
+		}
+		roots[0] = -c / b
+		return roots, 1
+	}
+
+	disc := b*b - 4*a*c
+	switch {
+	case disc < 0:
+		return roots, 0
+	case disc == 0:
+		roots[0] = -b / (2 * a)
+		return roots, 1
+	}
+
+	// the numerically stable form avoids the cancellation of b and the square root
+	q := -0.5 * (b + math.Copysign(math.Sqrt(disc), b))
+	roots[0], roots[1] = q/a, c/q
+	if roots[0] > roots[1] {
+		roots[0], roots[1] = roots[1], roots[0]
+	}
+	return roots, 2
+}
+
+// SolveCubic returns the real roots of ax³ + bx² + cx + d = 0 in ascending order and their number.
+// The quadratic equation is solved when a is 0.
+func SolveCubic(a, b, c, d float64) ([3]float64, int) {
+	var roots [3]float64
+	if a == 0 {
+		r, n := SolveQuadratic(b, c, d)
+		copy(roots[:], r[:n])
+		return roots, n
+	}
+
+	// the depressed cubic t³ + pt + q = 0 with x = t - b/3a
+	b, c, d = b/a, c/a, d/a
+	shift := b / 3
+	p := c - b*b/3
+	q := 2*b*b*b/27 - b*c/3 + d
+
+	var n int
+	disc := q*q/4 + p*p*p/27
+	switch {
+	case p == 0 && q == 0:
+		roots[0], n = 0, 1
+	case IsFloatsEqual(disc, 0, cubicEpsilon*(q*q/4+math.Abs(p*p*p/27))):
+		// a single and a double root
+		u := math.Cbrt(q / 2)
+		roots[0], roots[1], n = -2*u, u, 2
+	case disc > 0:
+		// one real root by Cardano's formula
+		s := math.Sqrt(disc)
+		roots[0], n = math.Cbrt(-q/2+s)+math.Cbrt(-q/2-s), 1
+	default:
+		// three real roots by the trigonometric method
+		m := 2 * math.Sqrt(-p/3)
+		theta := math.Acos(3*q/(p*m)) / 3
+		for k := 0; k < 3; k++ {
+			roots[k] = m * math.Cos(theta-2*math.Pi*float64(k)/3)
+		}
+		n = 3
+	}
+
+	for i := 0; i < n; i++ {
+		roots[i] -= shift
+	}
+	// sorting three values
+	for i := 1; i < n; i++ {
+		for j := i; j > 0 && roots[j] < roots[j-1]; j-- {
+			roots[j], roots[j-1] = roots[j-1], roots[j]
+		}
+	}
+	return roots, n
+}
+
+// Bisect returns the root of f in [lo, hi] within the tolerance,
+// false when f(lo) and f(hi) have the same sign or it does not converge within the iterations.
+func Bisect(f func(float64) float64, lo, hi, tol float64) (float64, bool) {
+	flo, fhi := f(lo), f(hi)
+	switch {
+	case flo == 0:
+		return lo, true
+	case fhi == 0:
+		return hi, true
+	case (flo < 0) == (fhi < 0):
+		return 0, false
+	}
+
+	for i := 0; i < solveIterations; i++ {
+		mid := lo + (hi-lo)/2
+		// the bracket is within the tolerance or the adjacent floats
+		if IsFloatsEqual(lo, hi, tol) || mid == lo || mid == hi {
+			return mid, true
+		}
+		fmid := f(mid)
+		if fmid == 0 {
+			return mid, true
+		}
+		if (fmid < 0) == (flo < 0) {
+			lo, flo = mid, fmid
+		} else {
+			hi = mid
+		}
+	}
+	return lo + (hi-lo)/2, false
+}
+
+// Newton returns the root of f near x by Newton's method with the derivative df,
+// false when the steps do not converge within the tolerance or the derivative is 0.
+func Newton(f, df func(float64) float64, x, tol float64) (float64, bool) {
+	for i := 0; i < solveIterations; i++ {
+		d := df(x)
+		if d == 0 {
+			return x, false
+		}
+		next := x - f(x)/d
+		if IsFloatsEqual(next, x, tol) {
+			return next, true
+		}
+		x = next
+	}
+	return x, false
+}
+
+// Brent returns the root of f in [lo, hi] within the tolerance by Brent's method,
+// false when f(lo) and f(hi) have the same sign. It converges faster than Bisect
+// on smooth functions and never slower.
+func Brent(f func(float64) float64, lo, hi, tol float64) (float64, bool) {
+	a, b := lo, hi
+	fa, fb := f(a), f(b)
+	switch {
+	case fa == 0:
+		return a, true
+	case fb == 0:
+		return b, true
+	case (fa < 0) == (fb < 0):
+		return 0, false
+	}
+
+	c, fc := a, fa
+	d := b - a
+	e := d
+	for i := 0; i < solveIterations; i++ {
+		// b is the best estimate, c is on the other side of the root
+		if (fb < 0) == (fc < 0) {
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		m := (c - b) / 2
+		if fb == 0 || IsFloatsEqual(m, 0, tol/2) {
+			return b, true
+		}
+
+		if math.Abs(e) >= tol/2 && math.Abs(fa) > math.Abs(fb) {
+			// the inverse quadratic interpolation or the secant
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				p = 2 * m * s
+				q = 1 - s
+			} else {
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*m*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < math.Min(3*m*q-math.Abs(tol*q/2), math.Abs(e*q)) {
+				e, d = d, p/q
+			} else {
+				d, e = m, m
+			}
+		} else {
+			d, e = m, m
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > tol/2 {
+			b += d
+		} else {
+			b += math.Copysign(tol/2, m)
+		}
+		fb = f(b)
+	}
+	return b, false
+}
+
+// invPhi is the inverse of the golden ratio.
+var invPhi = (math.Sqrt(5) - 1) / 2
+
+// GoldenSection returns the minimum of the unimodal f in [lo, hi] within the tolerance
+// or the best estimate after the iterations, the maximum is the minimum of -f.
+func GoldenSection(f func(float64) float64, lo, hi, tol float64) float64 {
+	x1 := hi - invPhi*(hi-lo)
+	x2 := lo + invPhi*(hi-lo)
+	f1, f2 := f(x1), f(x2)
+	for i := 0; i < solveIterations && !IsFloatsEqual(lo, hi, tol); i++ {
+		// the points stop moving at the float resolution
+		if !(lo < x1 && x1 < x2 && x2 < hi) {
+			break
+		}
+		if f1 < f2 {
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - invPhi*(hi-lo)
+			f1 = f(x1)
+		} else {
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + invPhi*(hi-lo)
+			f2 = f(x2)
+		}
+	}
+	return lo + (hi-lo)/2
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveQuadratic(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b, c float64
+		want    []float64
+	}{
+		{
+			name: `two`,
+			a:    1, b: -3, c: 2,
+			want: []float64{1, 2},
+		},
+		{
+			name: `double`,
+			a:    1, b: -2, c: 1,
+			want: []float64{1},
+		},
+		{
+			name: `none`,
+			a:    1, b: 0, c: 1,
+			want: []float64{},
+		},
+		{
+			name: `linear`,
+			a:    0, b: 2, c: -4,
+			want: []float64{2},
+		},
+		{
+			name: `constant`,
+			a:    0, b: 0, c: 1,
+			want: []float64{},
+		},
+		{
+			name: `negative a`,
+			a:    -1, b: 0, c: 4,
+			want: []float64{-2, 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			roots, n := SolveQuadratic(tc.a, tc.b, tc.c)
+			assert.InDeltaSlice(t, tc.want, roots[:n], 1e-12)
+		})
+	}
+}
+
+func TestSolveQuadratic_Stable(t *testing.T) {
+	// the textbook formula loses the small root to the cancellation
+	roots, n := SolveQuadratic(1, 1e8, 1)
+	assert.Equal(t, 2, n)
+	assert.InEpsilon(t, -1e-8, roots[1], 1e-12)
+	assert.InEpsilon(t, -1e8, roots[0], 1e-12)
+}
+
+func TestSolveCubic(t *testing.T) {
+	tests := []struct {
+		name       string
+		a, b, c, d float64
+		want       []float64
+	}{
+		{
+			name: `three`,
+			a:    1, b: -6, c: 11, d: -6,
+			want: []float64{1, 2, 3},
+		},
+		{
+			name: `one`,
+			a:    1, b: 0, c: 0, d: -8,
+			want: []float64{2},
+		},
+		{
+			name: `double`,
+			a:    1, b: -4, c: 5, d: -2,
+			want: []float64{1, 2},
+		},
+		{
+			name: `triple`,
+			a:    1, b: -3, c: 3, d: -1,
+			want: []float64{1},
+		},
+		{
+			name: `scaled`,
+			a:    -2, b: 0, c: 2, d: 0,
+			want: []float64{-1, 0, 1},
+		},
+		{
+			name: `quadratic`,
+			a:    0, b: 1, c: -3, d: 2,
+			want: []float64{1, 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			roots, n := SolveCubic(tc.a, tc.b, tc.c, tc.d)
+			assert.InDeltaSlice(t, tc.want, roots[:n], 1e-9)
+		})
+	}
+}
+
+func TestBisect(t *testing.T) {
+	f := func(x float64) float64 { return x*x - 2 }
+
+	got, ok := Bisect(f, 0, 2, 1e-9)
+	assert.True(t, ok)
+	assert.InDelta(t, math.Sqrt2, got, 1e-9)
+
+	got, ok = Bisect(f, 2, 0, 1e-9)
+	assert.True(t, ok)
+	assert.InDelta(t, math.Sqrt2, got, 1e-9)
+
+	got, ok = Bisect(func(x float64) float64 { return x - 1 }, 1, 2, 1e-9)
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, got)
+
+	_, ok = Bisect(f, 2, 3, 1e-9)
+	assert.False(t, ok)
+}
+
+func TestBisect_Limits(t *testing.T) {
+	f := func(x float64) float64 { return x*x - 2 }
+
+	// the tolerance below the float resolution stops at the adjacent floats
+	got, ok := Bisect(f, 0, 2, 0)
+	assert.True(t, ok)
+	assert.InDelta(t, math.Sqrt2, got, 1e-15)
+
+	got, ok = Bisect(func(x float64) float64 { return x - 1.5e9 }, 1e9, 2e9, 1e-9)
+	assert.True(t, ok)
+	assert.Equal(t, 1.5e9, got)
+
+	// not converged within the iterations
+	_, ok = Bisect(func(x float64) float64 { return x - 1 }, -1e300, 1e300, 1e-9)
+	assert.False(t, ok)
+}
+
+func TestNewton(t *testing.T) {
+	f := func(x float64) float64 { return x*x - 2 }
+	df := func(x float64) float64 { return 2 * x }
+
+	got, ok := Newton(f, df, 1, 1e-12)
+	assert.True(t, ok)
+	assert.InDelta(t, math.Sqrt2, got, 1e-12)
+
+	_, ok = Newton(f, df, 0, 1e-12)
+	assert.False(t, ok)
+
+	// no real root, the steps oscillate
+	_, ok = Newton(func(x float64) float64 { return x*x + 1 }, df, 1, 1e-12)
+	assert.False(t, ok)
+}
+
+func TestBrent(t *testing.T) {
+	tests := []struct {
+		name   string
+		f      func(float64) float64
+		lo, hi float64
+		want   float64
+	}{
+		{
+			name: `sqrt`,
+			f:    func(x float64) float64 { return x*x - 2 },
+			lo:   0, hi: 2,
+			want: math.Sqrt2,
+		},
+		{
+			name: `cos`,
+			f:    math.Cos,
+			lo:   0, hi: 3,
+			want: math.Pi / 2,
+		},
+		{
+			name: `flat`,
+			f:    func(x float64) float64 { return math.Pow(x-1, 5) },
+			lo:   -3, hi: 4,
+			want: 1,
+		},
+		{
+			name: `step`,
+			f: func(x float64) float64 {
+				if x < 0.3 {
+					return -1
+				}
+				return 1
+			},
+			lo: 0, hi: 1,
+			want: 0.3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := Brent(tc.f, tc.lo, tc.hi, 1e-9)
+			assert.True(t, ok)
+			assert.InDelta(t, tc.want, got, 1e-9)
+		})
+	}
+
+	_, ok := Brent(math.Cos, 2, 4, 1e-9)
+	assert.False(t, ok)
+}
+
+func TestBrent_MovingDistance(t *testing.T) {
+	// the time to fall 100m with the initial speed
+	f := func(time float64) float64 { return MovingDistance(-10, -3.711, time) + 100 }
+
+	got, ok := Brent(f, 0, 100, 1e-9)
+	assert.True(t, ok)
+	want, _ := MovingTime(-10, -3.711, -100)
+	assert.InDelta(t, want, got, 1e-9)
+}
+
+func TestGoldenSection(t *testing.T) {
+	got := GoldenSection(func(x float64) float64 { return (x - 1) * (x - 1) }, -5, 5, 1e-9)
+	assert.InDelta(t, 1, got, 1e-8)
+
+	// at the bound
+	got = GoldenSection(func(x float64) float64 { return x }, 0, 1, 1e-9)
+	assert.InDelta(t, 0, got, 1e-8)
+}
+
+func TestGoldenSection_Limits(t *testing.T) {
+	f := func(x float64) float64 { return (x - 1.5e9) * (x - 1.5e9) }
+
+	// the tolerance below the float resolution of the arguments
+	got := GoldenSection(f, 1e9, 2e9, 1e-9)
+	assert.InDelta(t, 1.5e9, got, 1e-3)
+
+	got = GoldenSection(func(x float64) float64 { return (x - 1) * (x - 1) }, -5, 5, 0)
+	assert.InDelta(t, 1, got, 1e-7)
+}
+
+func TestGoldenSection_Thrust(t *testing.T) {
+	// the thrust angle moving the most towards the target
+	target := Point{-1, 1}
+	got := GoldenSection(func(angle float64) float64 {
+		v := MovingVector(angle, 4)
+		return -(v.X*target.X + v.Y*target.Y)
+	}, -90, 90, 1e-6)
+	assert.InDelta(t, 45, got, 1e-5)
+}
+
+// Benchmarks
+
+func BenchmarkSolveQuadratic(b *testing.B) {
+	var roots [2]float64
+	for i := 0; i < b.N; i++ {
+		roots, _ = SolveQuadratic(1, float64(i%100), -1)
+	}
+	GlobalF = roots[0]
+}
+
+func BenchmarkBisect(b *testing.B) {
+	f := func(x float64) float64 { return x*x - 2 }
+	x := 0.0
+	for i := 0; i < b.N; i++ {
+		x, _ = Bisect(f, 0, 2, 1e-9)
+	}
+	GlobalF = x
+}
+
+func BenchmarkBrent(b *testing.B) {
+	f := func(x float64) float64 { return x*x - 2 }
+	x := 0.0
+	for i := 0; i < b.N; i++ {
+		x, _ = Brent(f, 0, 2, 1e-9)
+	}
+	GlobalF = x
+}
@@ -0,0 +1,138 @@
+package main
+
+// Measuring the time budget of a turn.
+// The Timer starts when the first byte of the turn input arrives, so the time
+// spent in the input buffers and in parsing is counted like the referee does.
+
+import (
+	"io"
+	"time"
+)
+
+// timer is the Timer of the current turn, reset by the Runner.
+var timer = NewTimer()
+
+// TimeBudget is the time limit of the turns.
+type TimeBudget struct {
+	// FirstTurn includes the game input and the Bot initialization.
+	FirstTurn time.Duration
+	Turn      time.Duration
+	// Margin is left for writing the output and the scheduling jitter.
+	Margin time.Duration
+}
+
+// DefaultTimeBudget returns the usual CodinGame limits.
+func DefaultTimeBudget() TimeBudget {
+	return TimeBudget{
+		FirstTurn: time.Second,
+		Turn:      50 * time.Millisecond,
+		Margin:    5 * time.Millisecond,
+	}
+}
+
+// Limit returns the time available for the turn.
+func (b TimeBudget) Limit(turn int) time.Duration {
+	limit := b.Turn
+	if turn == 0 {
+		limit = b.FirstTurn
+	}
+	return limit - b.Margin
+}
+
+// Timer tracks the deadline of a turn.
+type Timer struct {
+	// CheckEvery is the number of Check calls between the clock reads.
+	CheckEvery int
+
+	start    time.Time
+	deadline time.Time
+	checks   int
+	expired  bool
+}
+
+// NewTimer returns the Timer checking the clock every 256 calls.
+func NewTimer() *Timer {
+	return &Timer{CheckEvery: 256}
+}
+
+// Reset starts the Timer at the time with the limit.
+func (t *Timer) Reset(start time.Time, limit time.Duration) {
+	t.start = start
+	t.deadline = start.Add(limit)
+	t.checks = 0
+	t.expired = false
+}
+
+// Start returns the start time of the turn.
+func (t *Timer) Start() time.Time {
+	return t.start
+}
+
+// Elapsed returns the time since the start of the turn.
+func (t *Timer) Elapsed() time.Duration {
+	return time.Since(t.start)
+}
+
+// Remaining returns the time left until the deadline, negative when it has passed.
+func (t *Timer) Remaining() time.Duration {
+	return time.Until(t.deadline)
+}
+
+// Expired tests if the deadline has passed, once expired the Timer stays expired until the Reset.
+func (t *Timer) Expired() bool {
+	if !t.expired && !time.Now().Before(t.deadline) {
+		t.expired = true
+	}
+	return t.expired
+}
+
+// Check is the Expired for the tight loops reading the clock every CheckEvery calls.
+func (t *Timer) Check() bool {
+	t.checks++
+	if t.checks < t.CheckEvery {
+		return t.expired
+	}
+	t.checks = 0
+	return t.Expired()
+}
+
+// arrivalReader records the time the input arrives.
+type arrivalReader struct {
+	r io.Reader
+	// last is the time of the last read with data.
+	last time.Time
+	// arrival is the time of the first read with data since the mark.
+	arrival time.Time
+	marked  bool
+	// OnArrival is called on the first read with data since the mark.
+	OnArrival func()
+}
+
+func (a *arrivalReader) Read(p []byte) (int, error) {
+	n, err := a.r.Read(p)
+	if n > 0 {
+		a.last = time.Now()
+		if a.marked {
+			a.arrival = a.last
+			a.marked = false
+			if a.OnArrival != nil {
+				a.OnArrival()
+			}
+		}
+	}
+	return n, err
+}
+
+// Mark starts waiting for the next input.
+func (a *arrivalReader) Mark() {
+	a.marked = true
+}
+
+// Arrival returns the time the input since the mark has arrived,
+// the input already buffered has arrived with the last read.
+func (a *arrivalReader) Arrival() time.Time {
+	if a.marked {
+		return a.last
+	}
+	return a.arrival
+}
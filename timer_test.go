@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeBudget_Limit(t *testing.T) {
+	b := DefaultTimeBudget()
+	assert.Equal(t, 995*time.Millisecond, b.Limit(0))
+	assert.Equal(t, 45*time.Millisecond, b.Limit(1))
+}
+
+func TestTimer(t *testing.T) {
+	timer := NewTimer()
+	start := time.Now()
+	timer.Reset(start, time.Hour)
+	assert.Equal(t, start, timer.Start())
+	assert.False(t, timer.Expired())
+	assert.InDelta(t, time.Hour, timer.Remaining(), float64(time.Second))
+	assert.Less(t, timer.Elapsed(), time.Second)
+
+	timer.Reset(start.Add(-10*time.Millisecond), 5*time.Millisecond)
+	assert.True(t, timer.Expired())
+	assert.Less(t, timer.Remaining(), -4*time.Millisecond)
+}
+
+func TestTimer_Check(t *testing.T) {
+	timer := NewTimer()
+	timer.CheckEvery = 3
+	timer.Reset(time.Now(), -time.Millisecond)
+
+	assert.False(t, timer.Check())
+	assert.False(t, timer.Check())
+	assert.True(t, timer.Check())
+	assert.True(t, timer.Check())
+
+	timer.Reset(time.Now(), time.Hour)
+	for i := 0; i < 10; i++ {
+		assert.False(t, timer.Check())
+	}
+}
+
+func TestArrivalReader(t *testing.T) {
+	r := &arrivalReader{r: strings.NewReader("1\n2\n")}
+	s := bufio.NewScanner(r)
+
+	r.Mark()
+	s.Scan()
+	first := r.Arrival()
+	assert.False(t, first.IsZero())
+
+	// the second line is buffered with the first one
+	r.Mark()
+	s.Scan()
+	assert.Equal(t, first, r.Arrival())
+}
+
+// timerBot records the start of the turns.
+type timerBot struct {
+	timer  *Timer
+	starts []time.Time
+}
+
+func (b *timerBot) Init(game Game) {}
+
+func (b *timerBot) Turn(step Turn) Commands {
+	b.starts = append(b.starts, b.timer.Start())
+	return nil
+}
+
+func TestRunner_Timer(t *testing.T) {
+	pr, pw := io.Pipe()
+	bot := &timerBot{}
+	runner := newRunnerTests(t, bot, pr)
+	bot.timer = runner.Timer
+
+	var sent time.Time
+	go func() {
+		io.WriteString(pw, strings.Join(readGameTests, "\n")+"\n1 R L\n")
+		time.Sleep(20 * time.Millisecond)
+		sent = time.Now()
+		io.WriteString(pw, "2 L R\n")
+		pw.Close()
+	}()
+	runner.Run()
+
+	assert.Len(t, bot.starts, 2)
+	assert.Equal(t, runner.arrival, bot.starts[0])
+	assert.False(t, bot.starts[1].Before(sent))
+}
@@ -0,0 +1,66 @@
+package main
+
+// Precomputed sine and cosine of the angles in degrees.
+// Simulations trying thousands of integer angles look the values up instead
+// of calling math.Sin and math.Cos, the results are the same within 1e-14.
+
+import (
+	"math"
+)
+
+// degrees is the TrigTable of the integer degrees.
+var degrees = NewTrigTable(1)
+
+// TrigTable holds the sine of the angles in fixed steps of a degree.
+type TrigTable struct {
+	steps float64
+	// n is the number of the steps in the full turn
+	n int
+	// sin has a quarter turn more, the cosine is the sine shifted by it
+	sin []float64
+}
+
+// NewTrigTable returns the TrigTable of the steps per degree, 1 for the integer degrees.
+func NewTrigTable(steps int) *TrigTable {
+	n := 360 * steps
+	t := &TrigTable{steps: float64(steps), n: n, sin: make([]float64, n+n/4)}
+	for i := range t.sin {
+		t.sin[i] = math.Sin(float64(i%n) / t.steps * (math.Pi / 180))
+	}
+	return t
+}
+
+// index returns the index of the angle rounded to the nearest step.
+func (t *TrigTable) index(angle float64) int {
+	i := int(math.Round(angle*t.steps)) % t.n
+	if i < 0 {
+		i += t.n
+	}
+	return i
+}
+
+// Sin returns the sine of the angle in degrees rounded to the nearest step.
+func (t *TrigTable) Sin(angle float64) float64 {
+	return t.sin[t.index(angle)]
+}
+
+// Cos returns the cosine of the angle in degrees rounded to the nearest step.
+func (t *TrigTable) Cos(angle float64) float64 {
+	return t.sin[t.index(angle)+t.n/4]
+}
+
+// SinCos returns the sine and the cosine of the angle in degrees rounded to the nearest step.
+func (t *TrigTable) SinCos(angle float64) (float64, float64) {
+	i := t.index(angle)
+	return t.sin[i], t.sin[i+t.n/4]
+}
+
+// SinCosDeg returns the sine and the cosine of the integer angle in degrees.
+func (t *TrigTable) SinCosDeg(angle int) (float64, float64) {
+	i := angle % 360
+	if i < 0 {
+		i += 360
+	}
+	i *= t.n / 360
+	return t.sin[i], t.sin[i+t.n/4]
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrigTable_Degrees(t *testing.T) {
+	for angle := -720; angle <= 720; angle++ {
+		rad := float64(angle) * (math.Pi / 180)
+		sin, cos := degrees.SinCosDeg(angle)
+		assert.InDelta(t, math.Sin(rad), sin, 1e-14, angle)
+		assert.InDelta(t, math.Cos(rad), cos, 1e-14, angle)
+		assert.Equal(t, sin, degrees.Sin(float64(angle)))
+		assert.Equal(t, cos, degrees.Cos(float64(angle)))
+	}
+}
+
+func TestTrigTable_Steps(t *testing.T) {
+	table := NewTrigTable(4)
+	for _, angle := range []float64{0.25, 12.75, -33.5, 359.75, 721.25} {
+		rad := angle * (math.Pi / 180)
+		sin, cos := table.SinCos(angle)
+		assert.InDelta(t, math.Sin(rad), sin, 1e-14, angle)
+		assert.InDelta(t, math.Cos(rad), cos, 1e-14, angle)
+	}
+
+	// rounded to the nearest quarter of a degree
+	assert.Equal(t, table.Sin(12.25), table.Sin(12.3))
+	assert.Equal(t, table.Cos(-12.25), table.Cos(-12.2))
+	// the integer degrees are the same as of the degrees table
+	sin, cos := table.SinCosDeg(-135)
+	assert.Equal(t, sin, degrees.Sin(-135))
+	assert.Equal(t, cos, degrees.Cos(-135))
+}
+
+// Benchmarks
+
+func BenchmarkTrigTable_SinCosDeg(b *testing.B) {
+	var s, c float64
+	for i := 0; i < b.N; i++ {
+		s, c = degrees.SinCosDeg(i)
+	}
+	GlobalF = s + c
+}
+
+func BenchmarkTrigTable_SinCos(b *testing.B) {
+	table := NewTrigTable(10)
+	var s, c float64
+	for i := 0; i < b.N; i++ {
+		s, c = table.SinCos(float64(i) / 10)
+	}
+	GlobalF = s + c
+}
+
+func BenchmarkMath_Sincos(b *testing.B) {
+	var s, c float64
+	for i := 0; i < b.N; i++ {
+		s, c = math.Sincos(float64(i) * (math.Pi / 180))
+	}
+	GlobalF = s + c
+}
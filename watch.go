@@ -0,0 +1,174 @@
+package main
+
+// Watching named values over the turns of a match.
+// The history can be printed as a table or sparklines into the debug console
+// and exported every turn next to the turn data and with the crash dump.
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+var watches = NewWatches()
+
+// Watch is a named value with the per-turn history.
+type Watch struct {
+	Name    string
+	History []float64
+}
+
+// Last returns the last recorded value of the Watch.
+func (w *Watch) Last() float64 {
+	if len(w.History) == 0 {
+		return math.NaN()
+	}
+	return w.History[len(w.History)-1]
+}
+
+// Watches is a registry of watched values.
+type Watches struct {
+	turn  int
+	items []*Watch
+}
+
+// Turn returns the current turn of the registry.
+func (ws *Watches) Turn() int {
+	return ws.turn
+}
+
+// NextTurn moves the registry to the next turn.
+func (ws *Watches) NextTurn() {
+	ws.turn++
+}
+
+// Get returns the Watch by name or nil if it is not registered.
+func (ws *Watches) Get(name string) *Watch {
+	for _, w := range ws.items {
+		if w.Name == name {
+			return w
+		}
+	}
+	return nil
+}
+
+// Set records the value for the current turn,
+// turns without a value are filled with NaN.
+func (ws *Watches) Set(name string, value float64) {
+	w := ws.Get(name)
+	if w == nil {
+		w = &Watch{Name: name}
+		ws.items = append(ws.items, w)
+	}
+	for len(w.History) <= ws.turn {
+		w.History = append(w.History, math.NaN())
+	}
+	w.History[ws.turn] = value
+}
+
+// Table returns the history of the last turns as a text table.
+func (ws *Watches) Table(last int) string {
+	from := ws.turn - last + 1
+	if from < 0 {
+		from = 0
+	}
+
+	var b strings.Builder
+	b.WriteString("turn")
+	for _, w := range ws.items {
+		b.WriteString(" " + w.Name)
+	}
+	for turn := from; turn <= ws.turn; turn++ {
+		b.WriteString("\n" + strconv.Itoa(turn))
+		for _, w := range ws.items {
+			value := math.NaN()
+			if turn < len(w.History) {
+				value = w.History[turn]
+			}
+			b.WriteString(" " + formatWatch(value))
+		}
+	}
+
+	return b.String()
+}
+
+// Sparklines returns the history of every Watch as a sparkline with the last value.
+func (ws *Watches) Sparklines() string {
+	lines := make([]string, 0, len(ws.items))
+	for _, w := range ws.items {
+		lines = append(lines, fmt.Sprintf("%s %s %s", w.Name, Sparkline(w.History), formatWatch(w.Last())))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Export returns the history as lines of space separated values prefixed by the name,
+// suitable for DataExport.
+func (ws *Watches) Export() []string {
+	data := make([]string, 0, len(ws.items))
+	for _, w := range ws.items {
+		values := make([]string, 0, len(w.History)+1)
+		values = append(values, w.Name)
+		for _, v := range w.History {
+			values = append(values, formatWatch(v))
+		}
+		data = append(data, strings.Join(values, " "))
+	}
+	return data
+}
+
+// ExportTurn returns the values of the current turn as lines of the name and the value,
+// the Runner exports them every turn next to the turn data, so the history is
+// recovered from the debug output even when the match ends without a crash.
+func (ws *Watches) ExportTurn() []string {
+	data := make([]string, 0, len(ws.items))
+	for _, w := range ws.items {
+		value := math.NaN()
+		if ws.turn < len(w.History) {
+			value = w.History[ws.turn]
+		}
+		data = append(data, w.Name+" "+formatWatch(value))
+	}
+	return data
+}
+
+// Sparkline returns the values scaled to the block characters, NaN values are spaces.
+func Sparkline(values []float64) string {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+
+	line := make([]rune, 0, len(values))
+	for _, v := range values {
+		if math.IsNaN(v) {
+			line = append(line, ' ')
+			continue
+		}
+		i := 0
+		if hi > lo {
+			i = int(math.Round((v - lo) / (hi - lo) * float64(len(sparks)-1)))
+		}
+		line = append(line, sparks[i])
+	}
+
+	return string(line)
+}
+
+// formatWatch formats the value in the shortest form, NaN is a dash.
+func formatWatch(v float64) string {
+	if math.IsNaN(v) {
+		return "-"
+	}
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
+
+func NewWatches() *Watches {
+	return &Watches{}
+}
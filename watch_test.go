@@ -0,0 +1,73 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newWatchesTests() *Watches {
+	ws := NewWatches()
+	ws.Set("depth", 1)
+	ws.Set("score", 0.5)
+	ws.NextTurn()
+	ws.Set("depth", 3)
+	ws.NextTurn()
+	ws.Set("depth", 2)
+	ws.Set("score", 1.5)
+	return ws
+}
+
+func TestWatches_Set(t *testing.T) {
+	ws := newWatchesTests()
+	assert.Equal(t, 2, ws.Turn())
+	assert.Equal(t, []float64{1, 3, 2}, ws.Get("depth").History)
+
+	score := ws.Get("score").History
+	assert.Len(t, score, 3)
+	assert.True(t, math.IsNaN(score[1]))
+	assert.Equal(t, 1.5, ws.Get("score").Last())
+
+	assert.Nil(t, ws.Get("unknown"))
+}
+
+func TestWatch_Last(t *testing.T) {
+	w := &Watch{}
+	assert.True(t, math.IsNaN(w.Last()))
+}
+
+func TestWatches_Table(t *testing.T) {
+	ws := newWatchesTests()
+	assert.Equal(t, "turn depth score\n1 3 -\n2 2 1.5", ws.Table(2))
+	assert.Equal(t, "turn depth score\n0 1 0.5\n1 3 -\n2 2 1.5", ws.Table(10))
+}
+
+func TestWatches_Sparklines(t *testing.T) {
+	ws := newWatchesTests()
+	assert.Equal(t, "depth ▁█▅ 2\nscore ▁ █ 1.5", ws.Sparklines())
+}
+
+func TestWatches_Export(t *testing.T) {
+	ws := newWatchesTests()
+	want := []string{
+		"depth 1 3 2",
+		"score 0.5 - 1.5",
+	}
+	assert.Equal(t, want, ws.Export())
+}
+
+func TestWatches_ExportTurn(t *testing.T) {
+	ws := newWatchesTests()
+	assert.Equal(t, []string{"depth 2", "score 1.5"}, ws.ExportTurn())
+
+	ws.NextTurn()
+	assert.Equal(t, []string{"depth -", "score -"}, ws.ExportTurn())
+	assert.Empty(t, NewWatches().ExportTurn())
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "", Sparkline(nil))
+	assert.Equal(t, "▁▁", Sparkline([]float64{5, 5}))
+	assert.Equal(t, "▁▅█", Sparkline([]float64{0, 5, 10}))
+}